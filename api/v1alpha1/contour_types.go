@@ -14,8 +14,11 @@
 package v1alpha1
 
 import (
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
@@ -31,12 +34,32 @@ const (
 
 	// ContourFinalizer is the name of the finalizer used for a Contour.
 	ContourFinalizer = "contour.operator.projectcontour.io/finalizer"
+
+	// PausedKindsAnnotation is the annotation used to pause reconciliation of
+	// specific kinds of child resources of a Contour. The value is a
+	// comma-separated list of kinds, e.g. "Service,DaemonSet". Supported kinds
+	// are Secret, ConfigMap, Job, Deployment, DaemonSet, HorizontalPodAutoscaler,
+	// PodDisruptionBudget and Service.
+	PausedKindsAnnotation = "operator.projectcontour.io/paused-kinds"
+
+	// ManagedNamespaceLabelsAnnotation is the annotation recording the keys of
+	// the spec.namespace.labels applied to the namespace by the operator, so
+	// they are removed once no longer specified. The value is a comma-separated
+	// list of label keys.
+	ManagedNamespaceLabelsAnnotation = "contour.operator.projectcontour.io/managed-namespace-labels"
+
+	// ManagedNamespaceAnnotationsAnnotation is the annotation recording the keys
+	// of the spec.namespace.annotations applied to the namespace by the operator,
+	// so they are removed once no longer specified. The value is a
+	// comma-separated list of annotation keys.
+	ManagedNamespaceAnnotationsAnnotation = "contour.operator.projectcontour.io/managed-namespace-annotations"
 )
 
 // +kubebuilder:object:root=true
 
 // Contour is the Schema for the contours API.
 // +kubebuilder:subresource:status
+// +kubebuilder:subresource:scale:specpath=.spec.replicas,statuspath=.status.replicas,selectorpath=.status.selector
 // +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Available")].status`
 // +kubebuilder:printcolumn:name="Reason",type=string,JSONPath=`.status.conditions[?(@.type=="Available")].reason`
 type Contour struct {
@@ -63,6 +86,11 @@ type ContourSpec struct {
 	// Replicas is the desired number of Contour replicas. If unset,
 	// defaults to 2.
 	//
+	// Replicas backs the scale subresource of the Contour, so it can be set
+	// with "kubectl scale". A HorizontalPodAutoscaler targeting the Contour
+	// only works when spec.namespace.name is the namespace of the Contour;
+	// see status.selector.
+	//
 	// +kubebuilder:default=2
 	// +kubebuilder:validation:Minimum=0
 	Replicas int32 `json:"replicas,omitempty"`
@@ -86,114 +114,1312 @@ type ContourSpec struct {
 	//
 	// +kubebuilder:validation:MaxLength=253
 	// +optional
-	GatewayClassRef *string `json:"gatewayClassRef,omitempty"`
+	GatewayClassRef *string `json:"gatewayClassRef,omitempty"`
+
+	// GatewayControllerName is used to determine which GatewayClass
+	// Contour reconciles. The string takes the form of
+	// "projectcontour.io/<namespace>/contour". If unset, Contour will not
+	// reconcile Gateway API resources.
+	//
+	// +kubebuilder:validation:MaxLength=253
+	// +optional
+	GatewayControllerName *string `json:"gatewayControllerName,omitempty"`
+
+	// IngressClassName is the name of the IngressClass used by Contour. If unset,
+	// Contour will process all ingress objects without an ingress class annotation
+	// or ingress objects with an annotation matching ingress-class=contour. When
+	// specified, Contour will only process ingress objects that match the provided
+	// class.
+	//
+	// For additional IngressClass details, refer to:
+	//   https://projectcontour.io/docs/main/config/annotations/#ingress-class
+	//
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	// +optional
+	IngressClassName *string `json:"ingressClassName,omitempty"`
+
+	// CreateIngressClass creates an IngressClass named ingressClassName for
+	// Contour, so Ingresses can select Contour through their
+	// spec.ingressClassName field. The IngressClass is deleted with the
+	// Contour, and an existing IngressClass of the same name that the
+	// operator doesn't own is left untouched. Requires ingressClassName.
+	//
+	// +optional
+	CreateIngressClass bool `json:"createIngressClass,omitempty"`
+
+	// RootNamespaces restricts root HTTPProxies, i.e. those defining a
+	// virtual host, to the given namespaces. HTTPProxies in other namespaces
+	// can still be included by a root HTTPProxy. Contour keeps watching all
+	// namespaces for included HTTPProxies, Services and Secrets, so its
+	// cluster-wide permissions are unchanged.
+	//
+	// If unset, root HTTPProxies are allowed in all namespaces.
+	//
+	// +kubebuilder:validation:MaxItems=64
+	// +optional
+	RootNamespaces []string `json:"rootNamespaces,omitempty"`
+
+	// NodePlacement enables scheduling of Contour and Envoy pods onto specific nodes.
+	//
+	// See each field for additional details.
+	//
+	// +optional
+	NodePlacement *NodePlacement `json:"nodePlacement,omitempty"`
+
+	// Image overrides the operator's default Contour and Envoy images for
+	// this Contour, e.g. to run a different version.
+	//
+	// See each field for additional details.
+	//
+	// +optional
+	Image *ImageSettings `json:"image,omitempty"`
+
+	// Certgen defines the settings of the certificates generated for the
+	// TLS connection between Contour and Envoy.
+	//
+	// +optional
+	Certgen *CertgenSettings `json:"certgen,omitempty"`
+
+	// ImagePullSecretRefs are references to secrets in spec.namespace.name
+	// used to pull the Contour and Envoy images, e.g. from a private registry.
+	//
+	// +kubebuilder:validation:MaxItems=8
+	// +optional
+	ImagePullSecretRefs []ImagePullSecretRef `json:"imagePullSecretRefs,omitempty"`
+
+	// Resources defines the compute resources of the containers managed
+	// for Contour and Envoy.
+	//
+	// See each field for additional details.
+	//
+	// +optional
+	Resources *ContainerResources `json:"resources,omitempty"`
+
+	// EnableExternalNameService enables ExternalName Services.
+	// ExternalName Services are disabled by default due to CVE-2021-XXXXX
+	// You can re-enable them by setting this setting to "true".
+	// This is not recommended without understanding the security implications.
+	// Please see the advisory at https://github.com/projectcontour/contour/security/advisories/GHSA-5ph6-qq5x-7jwc for the details.
+	//
+	// +optional
+	EnableExternalNameService *bool `json:"enableExternalNameService,omitempty"`
+
+	// DisablePermitInsecure disables the permitInsecure field of HTTPProxy
+	// routes, so routes of secure virtual hosts are never served over plain
+	// HTTP regardless of what HTTPProxy authors request. Changing it
+	// restarts Contour, which only reads its configuration at startup.
+	//
+	// If unset, defaults to false.
+	//
+	// +optional
+	DisablePermitInsecure *bool `json:"disablePermitInsecure,omitempty"`
+
+	// Contour contains settings applied to the Contour pods.
+	//
+	// See each field for additional details.
+	//
+	// +optional
+	Contour *ContourSettings `json:"contour,omitempty"`
+
+	// Envoy contains settings applied to the Envoy proxies managed by Contour.
+	//
+	// See each field for additional details.
+	//
+	// +optional
+	Envoy *EnvoySettings `json:"envoy,omitempty"`
+
+	// TTLSecondsAfterCreation is the lifetime of the Contour. Once it has
+	// elapsed since the Contour was created, the operator deletes the
+	// Contour, which removes its resources including the Envoy load
+	// balancer. Intended for throwaway environments, e.g. provisioned by CI.
+	//
+	// If unset, the Contour does not expire.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	TTLSecondsAfterCreation *int32 `json:"ttlSecondsAfterCreation,omitempty"`
+
+	// ProxyConfig defines the HTTP proxy used for outbound connections of
+	// Contour and the certgen Job, e.g. in networks where direct egress is
+	// blocked. It does not apply to traffic proxied by Envoy.
+	//
+	// +optional
+	ProxyConfig *ProxyConfig `json:"proxyConfig,omitempty"`
+}
+
+// ProxyConfig defines an HTTP proxy, passed to containers through the
+// HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables.
+type ProxyConfig struct {
+	// HTTPProxy is the URL of the proxy for HTTP requests.
+	//
+	// +optional
+	HTTPProxy string `json:"httpProxy,omitempty"`
+
+	// HTTPSProxy is the URL of the proxy for HTTPS requests.
+	//
+	// +optional
+	HTTPSProxy string `json:"httpsProxy,omitempty"`
+
+	// NoProxy is a comma-separated list of hostnames, domains, IP addresses
+	// and CIDRs that are reached without the proxy. The address of the
+	// Kubernetes API server is always appended.
+	//
+	// +optional
+	NoProxy string `json:"noProxy,omitempty"`
+}
+
+// NodePlacement describes node scheduling configuration of Contour and Envoy pods.
+type NodePlacement struct {
+	// Contour describes node scheduling configuration of Contour pods.
+	//
+	// +optional
+	Contour *ContourNodePlacement `json:"contour,omitempty"`
+
+	// Envoy describes node scheduling configuration of Envoy pods.
+	//
+	// +optional
+	Envoy *EnvoyNodePlacement `json:"envoy,omitempty"`
+}
+
+// CertgenSettings defines the settings of the certificates generated for
+// Contour and Envoy. Certificates are regenerated when these settings change.
+type CertgenSettings struct {
+	// CertificateLifetime is the validity period of generated certificates,
+	// in days.
+	//
+	// If unset, defaults to 365.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	CertificateLifetime *int32 `json:"certificateLifetime,omitempty"`
+}
+
+// ImageSettings defines the images of the containers managed for Contour.
+type ImageSettings struct {
+	// Contour overrides the image used by Contour, certgen and the Envoy
+	// shutdown-manager and initconfig containers.
+	//
+	// +optional
+	Contour *ImageOverride `json:"contour,omitempty"`
+
+	// Envoy overrides the image used by Envoy.
+	//
+	// +optional
+	Envoy *ImageOverride `json:"envoy,omitempty"`
+}
+
+// ImageOverride overrides parts of an image reference. Unset fields keep
+// the corresponding part of the operator's default image.
+type ImageOverride struct {
+	// Repository is the image repository, e.g. "registry.example.com/contour".
+	//
+	// +optional
+	Repository string `json:"repository,omitempty"`
+
+	// Tag is the image tag. Mutually exclusive with digest.
+	//
+	// +optional
+	Tag string `json:"tag,omitempty"`
+
+	// Digest is the image digest, e.g. "sha256:...". Mutually exclusive
+	// with tag.
+	//
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]+:[a-f0-9]+$`
+	// +optional
+	Digest string `json:"digest,omitempty"`
+
+	// PullPolicy is the image pull policy of the containers using the image.
+	//
+	// +kubebuilder:validation:Enum=Always;IfNotPresent;Never
+	// +optional
+	PullPolicy corev1.PullPolicy `json:"pullPolicy,omitempty"`
+}
+
+// ImagePullSecretRef is a reference to an image pull secret.
+type ImagePullSecretRef struct {
+	// Name is the name of the secret.
+	//
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Mirror copies the secret of the same name from the operator namespace
+	// into spec.namespace.name and keeps the copy in sync, so the credentials
+	// don't need to be duplicated for every Contour. If the operator namespace
+	// is not known, the secret is copied from the namespace of the Contour.
+	//
+	// If unset, the secret must already exist in spec.namespace.name.
+	//
+	// +optional
+	Mirror bool `json:"mirror,omitempty"`
+}
+
+// ContainerResources defines the compute resources of the containers
+// managed for Contour and Envoy. If unset, containers have no requests or
+// limits and run with BestEffort QoS.
+type ContainerResources struct {
+	// Contour defines the compute resources of the Contour container.
+	//
+	// +optional
+	Contour corev1.ResourceRequirements `json:"contour,omitempty"`
+
+	// Envoy defines the compute resources of the Envoy container.
+	//
+	// +optional
+	Envoy corev1.ResourceRequirements `json:"envoy,omitempty"`
+
+	// ShutdownManager defines the compute resources of the shutdown-manager
+	// container running alongside Envoy.
+	//
+	// +optional
+	ShutdownManager corev1.ResourceRequirements `json:"shutdownManager,omitempty"`
+
+	// EnvoyInitConfig defines the compute resources of the envoy-initconfig
+	// init container that generates Envoy's bootstrap configuration.
+	//
+	// +optional
+	EnvoyInitConfig corev1.ResourceRequirements `json:"envoyInitConfig,omitempty"`
+}
+
+// ContourNodePlacement describes node scheduling configuration for Contour pods.
+// If nodeSelector and tolerations are specified, the scheduler will use both to
+// determine where to place the Contour pod(s).
+type ContourNodePlacement struct {
+	// NodeSelector is the simplest recommended form of node selection constraint
+	// and specifies a map of key-value pairs. For the Contour pod to be eligible
+	// to run on a node, the node must have each of the indicated key-value pairs
+	// as labels (it can have additional labels as well).
+	//
+	// If unset, the Contour pod(s) will be scheduled to any available node.
+	//
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Tolerations work with taints to ensure that Envoy pods are not scheduled
+	// onto inappropriate nodes. One or more taints are applied to a node; this
+	// marks that the node should not accept any pods that do not tolerate the
+	// taints.
+	//
+	// The default is an empty list.
+	//
+	// See https://kubernetes.io/docs/concepts/configuration/taint-and-toleration/
+	// for additional details.
+	//
+	// +optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// Affinity is the scheduling affinity of Contour pods, e.g. to require
+	// control-plane or infra nodes.
+	//
+	// If podAntiAffinity is unset, Contour pods keep the default preference
+	// for spreading across nodes.
+	//
+	// +optional
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+
+	// TopologySpreadConstraints describe how Contour pods are spread across
+	// topology domains, e.g. zones. Constraints without a labelSelector
+	// select the Contour pods.
+	//
+	// +optional
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+}
+
+// EnvoyNodePlacement describes node scheduling configuration for Envoy pods.
+// If nodeSelector and tolerations are specified, the scheduler will use both
+// to determine where to place the Envoy pod(s).
+type EnvoyNodePlacement struct {
+	// NodeSelector is the simplest recommended form of node selection constraint
+	// and specifies a map of key-value pairs. For the Envoy pod to be eligible to
+	// run on a node, the node must have each of the indicated key-value pairs as
+	// labels (it can have additional labels as well).
+	//
+	// If unset, the Envoy pod(s) will be scheduled to any available node.
+	//
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Tolerations work with taints to ensure that Envoy pods are not scheduled
+	// onto inappropriate nodes. One or more taints are applied to a node; this
+	// marks that the node should not accept any pods that do not tolerate the taints.
+	//
+	// The default is an empty list.
+	//
+	// See https://kubernetes.io/docs/concepts/configuration/taint-and-toleration/
+	// for additional details.
+	//
+	// +optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// Affinity is the scheduling affinity of Envoy pods.
+	//
+	// If podAntiAffinity is unset and the Envoy workload type is
+	// "Deployment", Envoy pods keep the default preference for spreading
+	// across nodes.
+	//
+	// +optional
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+
+	// TopologySpreadConstraints describe how Envoy pods are spread across
+	// topology domains, e.g. zones. Constraints without a labelSelector
+	// select the Envoy pods.
+	//
+	// +optional
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+}
+
+// EnvoySettings contains settings applied to the Envoy proxies managed by Contour.
+type EnvoySettings struct {
+	// WorkloadType is the type of workload used to run Envoy. Allowed values
+	// are "DaemonSet", which runs an Envoy pod on every eligible node, and
+	// "Deployment", which runs a fixed number of Envoy pods spread across
+	// nodes.
+	//
+	// If unset, defaults to "DaemonSet".
+	//
+	// +optional
+	WorkloadType EnvoyWorkloadType `json:"workloadType,omitempty"`
+
+	// NetworkType is how Envoy pods are exposed on the network. Allowed
+	// values are "ClusterIP", which exposes Envoy through the Envoy Service
+	// described by networkPublishing, "HostPorts", which binds the "http"
+	// and "https" container ports to ports 80 and 443 of the node and extra
+	// ports to their port numbers, and "HostNetwork", which runs Envoy in the
+	// network namespace of the node so Envoy's listeners bind the node's
+	// ports directly.
+	//
+	// With "HostNetwork", Envoy listens on the node at its containerPorts,
+	// 8080 and 8443 by default, rather than 80 and 443. Envoy runs without
+	// privileges, so container ports below 1024 are rejected unless
+	// securityContext or podSecurityContext grants Envoy the privileges to
+	// bind them.
+	//
+	// The Envoy Service is not created for "HostPorts" and "HostNetwork".
+	// With workloadType "Deployment", at most one replica runs per node;
+	// replicas beyond the nodes Envoy can be scheduled to remain Pending.
+	//
+	// If unset, defaults to "ClusterIP".
+	//
+	// +optional
+	NetworkType EnvoyNetworkType `json:"networkType,omitempty"`
+
+	// Replicas is the desired number of Envoy replicas. Only applies when
+	// workloadType is "Deployment".
+	//
+	// If unset, defaults to 2.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// UpdateStrategy is the update strategy of the Envoy DaemonSet, e.g. to
+	// limit how many Envoy pods are replaced at once in large clusters. Only
+	// applies when workloadType is "DaemonSet".
+	//
+	// If unset, Envoy pods are replaced by a rolling update with at most 10%
+	// of the pods unavailable.
+	//
+	// +optional
+	UpdateStrategy *appsv1.DaemonSetUpdateStrategy `json:"updateStrategy,omitempty"`
+
+	// Autoscaling enables a HorizontalPodAutoscaler for Envoy. Only applies
+	// when workloadType is "Deployment". While autoscaling is enabled, the
+	// operator leaves the replica count of the Envoy Deployment to the
+	// autoscaler.
+	//
+	// +optional
+	Autoscaling *EnvoyAutoscaling `json:"autoscaling,omitempty"`
+
+	// TLS defines the TLS settings of Envoy listeners, i.e. the TLS
+	// connections between clients and Envoy. Changing them restarts
+	// Contour, which only reads its configuration at startup.
+	//
+	// +optional
+	TLS *EnvoyTLS `json:"tls,omitempty"`
+
+	// HeadersPolicy defines headers Envoy sets on or removes from all
+	// requests and responses proxied for HTTPProxies, e.g. to always strip
+	// a header set by clients. Changing it restarts Contour, which only
+	// reads its configuration at startup.
+	//
+	// +optional
+	HeadersPolicy *GlobalHeadersPolicy `json:"headersPolicy,omitempty"`
+
+	// RateLimitService enables global rate limiting by Envoy through the
+	// given rate limit service. HTTPProxies then define global rate limit
+	// policies whose descriptors are sent to the service. Changing it
+	// restarts Contour, which only reads its configuration at startup.
+	//
+	// If unset, global rate limiting is disabled.
+	//
+	// +optional
+	RateLimitService *RateLimitServiceSettings `json:"rateLimitService,omitempty"`
+
+	// AccessLog defines the format and verbosity of Envoy access logs.
+	// Changing it restarts Contour, which only reads its configuration at
+	// startup.
+	//
+	// +optional
+	AccessLog *EnvoyAccessLog `json:"accessLog,omitempty"`
+
+	// Compression defines the compression applied by Envoy to HTTP responses.
+	//
+	// +optional
+	Compression *EnvoyCompression `json:"compression,omitempty"`
+
+	// Listener defines the settings of Envoy listeners, i.e. the connections
+	// between clients and Envoy.
+	//
+	// +optional
+	Listener *EnvoyListenerSettings `json:"listener,omitempty"`
+
+	// Cluster defines the settings of Envoy clusters, i.e. the connections
+	// between Envoy and upstream services.
+	//
+	// +optional
+	Cluster *EnvoyClusterSettings `json:"cluster,omitempty"`
+
+	// Network defines the network settings of Envoy.
+	//
+	// +optional
+	Network *EnvoyNetworkSettings `json:"network,omitempty"`
+
+	// ServerHeaderTransformation defines how Envoy handles the Server header
+	// of HTTP responses. Allowed values are "overwrite", which replaces the
+	// header with "envoy", "append_if_absent", which sets it to "envoy" only
+	// when the upstream didn't set it, and "pass_through", which never
+	// modifies it.
+	//
+	// If unset, defaults to "overwrite".
+	//
+	// +optional
+	ServerHeaderTransformation ServerHeaderTransformationType `json:"serverHeaderTransformation,omitempty"`
+
+	// DefaultHTTPVersions are the HTTP versions Envoy offers to clients.
+	// Allowed values are "HTTP/1.1" and "HTTP/2". Setting only "HTTP/1.1"
+	// disables HTTP/2 for clients, e.g. for legacy clients that misbehave
+	// with it. Changing them restarts Contour, which only reads its
+	// configuration at startup.
+	//
+	// If unset, both HTTP/1.1 and HTTP/2 are offered.
+	//
+	// +kubebuilder:validation:MaxItems=2
+	// +listType=set
+	// +optional
+	DefaultHTTPVersions []HTTPVersionType `json:"defaultHTTPVersions,omitempty"`
+
+	// Timeouts defines the timeouts Envoy applies to client connections and
+	// requests. Changing them restarts Contour, which only reads its
+	// configuration at startup.
+	//
+	// +optional
+	Timeouts *EnvoyTimeouts `json:"timeouts,omitempty"`
+
+	// PodAnnotations are annotations added to Envoy pods. They take
+	// precedence over annotations set by the operator, e.g. the Prometheus
+	// scrape annotations.
+	//
+	// +optional
+	PodAnnotations map[string]string `json:"podAnnotations,omitempty"`
+
+	// PodLabels are labels added to Envoy pods. Labels used by the operator
+	// to select Envoy pods can't be overridden.
+	//
+	// +optional
+	PodLabels map[string]string `json:"podLabels,omitempty"`
+
+	// PriorityClassName is the name of the PriorityClass of Envoy pods.
+	//
+	// +optional
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+
+	// PodSecurityContext is the security context of Envoy pods.
+	//
+	// If unset, pods run as user and group 65534 with the runtime's default
+	// seccomp profile, which satisfies the "restricted" Pod Security
+	// Standard.
+	//
+	// +optional
+	PodSecurityContext *corev1.PodSecurityContext `json:"podSecurityContext,omitempty"`
+
+	// SecurityContext is the security context of the containers managed for
+	// Envoy: "envoy", "shutdown-manager" and "envoy-initconfig". It does not
+	// apply to extraContainers.
+	//
+	// If unset, containers drop all capabilities and disallow privilege
+	// escalation, which satisfies the "restricted" Pod Security Standard.
+	//
+	// +optional
+	SecurityContext *corev1.SecurityContext `json:"securityContext,omitempty"`
+
+	// DNSPolicy is the DNS policy of Envoy pods.
+	//
+	// If unset, defaults to "ClusterFirstWithHostNet" when networkType is
+	// "HostNetwork", and to "ClusterFirst" otherwise.
+	//
+	// +kubebuilder:validation:Enum=ClusterFirstWithHostNet;ClusterFirst;Default;None
+	// +optional
+	DNSPolicy corev1.DNSPolicy `json:"dnsPolicy,omitempty"`
+
+	// DNSConfig defines DNS parameters of Envoy pods, e.g. the nameservers
+	// and search domains of a node-local DNS cache. They are merged with
+	// those generated from dnsPolicy, which must be "None" for dnsConfig
+	// alone to apply.
+	//
+	// +optional
+	DNSConfig *corev1.PodDNSConfig `json:"dnsConfig,omitempty"`
+
+	// TerminationGracePeriodSeconds is the time Envoy pods are given to
+	// drain connections before they are killed. It should exceed the time
+	// long-lived connections, e.g. websockets or gRPC streams, take to
+	// close.
+	//
+	// If unset, defaults to 300.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	TerminationGracePeriodSeconds *int64 `json:"terminationGracePeriodSeconds,omitempty"`
+
+	// Shutdown configures how the shutdown-manager drains Envoy before its
+	// pods terminate.
+	//
+	// +optional
+	Shutdown *EnvoyShutdownSettings `json:"shutdown,omitempty"`
+
+	// Bootstrap configures the bootstrap configuration generated for Envoy
+	// by the envoy-initconfig init container.
+	//
+	// +optional
+	Bootstrap *EnvoyBootstrapSettings `json:"bootstrap,omitempty"`
+
+	// ReadinessProbe tunes the readiness probe of the Envoy container.
+	//
+	// +optional
+	ReadinessProbe *ProbeSettings `json:"readinessProbe,omitempty"`
+
+	// Concurrency sets the number of Envoy worker threads.
+	//
+	// If unset, Envoy runs one worker thread per core of the node.
+	//
+	// +optional
+	Concurrency *EnvoyConcurrency `json:"concurrency,omitempty"`
+
+	// ExtraVolumes are volumes added to Envoy pods, e.g. a CA bundle or Lua
+	// filter sources. Names must not collide with the volumes managed by
+	// the operator: "envoycert", "envoy-config" and "envoy-admin".
+	//
+	// +optional
+	ExtraVolumes []corev1.Volume `json:"extraVolumes,omitempty"`
+
+	// ExtraVolumeMounts are volume mounts added to the Envoy container.
+	//
+	// +optional
+	ExtraVolumeMounts []corev1.VolumeMount `json:"extraVolumeMounts,omitempty"`
+
+	// ExtraEnv are environment variables added to the Envoy container, e.g.
+	// HTTP_PROXY. Names must not collide with the variables managed by the
+	// operator: "CONTOUR_NAMESPACE" and "ENVOY_POD_NAME".
+	//
+	// +optional
+	ExtraEnv []corev1.EnvVar `json:"extraEnv,omitempty"`
+
+	// ExtraContainers are containers added to Envoy pods, e.g. a log
+	// shipper. Names must not collide with the containers managed by the
+	// operator: "envoy", "shutdown-manager" and "envoy-initconfig".
+	//
+	// +optional
+	ExtraContainers []corev1.Container `json:"extraContainers,omitempty"`
+
+	// ExtraInitContainers are init containers added to Envoy pods. They
+	// run after the init container that bootstraps Envoy. Names must not
+	// collide with the containers managed by the operator.
+	//
+	// +optional
+	ExtraInitContainers []corev1.Container `json:"extraInitContainers,omitempty"`
+
+	// PodDisruptionBudget enables a PodDisruptionBudget for Envoy pods. Only
+	// applies when workloadType is "Deployment", since voluntary evictions
+	// don't apply to DaemonSet pods.
+	//
+	// +optional
+	PodDisruptionBudget *PodDisruptionBudgetSettings `json:"podDisruptionBudget,omitempty"`
+}
+
+// ContourSettings contains settings applied to the Contour pods.
+type ContourSettings struct {
+	// PodAnnotations are annotations added to Contour pods. They take
+	// precedence over annotations set by the operator, e.g. the Prometheus
+	// scrape annotations.
+	//
+	// +optional
+	PodAnnotations map[string]string `json:"podAnnotations,omitempty"`
+
+	// PodLabels are labels added to Contour pods. Labels used by the
+	// operator to select Contour pods can't be overridden.
+	//
+	// +optional
+	PodLabels map[string]string `json:"podLabels,omitempty"`
+
+	// PriorityClassName is the name of the PriorityClass of Contour pods.
+	//
+	// +optional
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+
+	// PodSecurityContext is the security context of Contour pods.
+	//
+	// If unset, pods run as user and group 65534 with the runtime's default
+	// seccomp profile, which satisfies the "restricted" Pod Security
+	// Standard.
+	//
+	// +optional
+	PodSecurityContext *corev1.PodSecurityContext `json:"podSecurityContext,omitempty"`
+
+	// SecurityContext is the security context of the "contour" container.
+	// It does not apply to extraContainers.
+	//
+	// If unset, the container drops all capabilities and disallows
+	// privilege escalation, which satisfies the "restricted" Pod Security
+	// Standard.
+	//
+	// +optional
+	SecurityContext *corev1.SecurityContext `json:"securityContext,omitempty"`
+
+	// DNSPolicy is the DNS policy of Contour pods.
+	//
+	// If unset, defaults to "ClusterFirst".
+	//
+	// +kubebuilder:validation:Enum=ClusterFirstWithHostNet;ClusterFirst;Default;None
+	// +optional
+	DNSPolicy corev1.DNSPolicy `json:"dnsPolicy,omitempty"`
+
+	// DNSConfig defines DNS parameters of Contour pods. They are merged with
+	// those generated from dnsPolicy, which must be "None" for dnsConfig
+	// alone to apply.
+	//
+	// +optional
+	DNSConfig *corev1.PodDNSConfig `json:"dnsConfig,omitempty"`
+
+	// PodDisruptionBudget enables a PodDisruptionBudget for Contour pods.
+	//
+	// +optional
+	PodDisruptionBudget *PodDisruptionBudgetSettings `json:"podDisruptionBudget,omitempty"`
+
+	// ExtraVolumes are volumes added to Contour pods, e.g. a CA bundle.
+	// Names must not collide with the volumes managed by the operator:
+	// "contourcert", "contour-config" and "metrics-certs".
+	//
+	// +optional
+	ExtraVolumes []corev1.Volume `json:"extraVolumes,omitempty"`
+
+	// ExtraVolumeMounts are volume mounts added to the Contour container.
+	//
+	// +optional
+	ExtraVolumeMounts []corev1.VolumeMount `json:"extraVolumeMounts,omitempty"`
+
+	// ExtraEnv are environment variables added to the Contour container,
+	// e.g. GOMAXPROCS. Names must not collide with the variables managed by
+	// the operator: "CONTOUR_NAMESPACE", "POD_NAME" and, if proxyConfig is
+	// set, the proxy variables.
+	//
+	// +optional
+	ExtraEnv []corev1.EnvVar `json:"extraEnv,omitempty"`
+
+	// ExtraContainers are containers added to Contour pods, e.g. audit log
+	// shippers or security agents. Names must not collide with the
+	// "contour" container managed by the operator, and container ports
+	// must not collide with the ports bound by Contour: 8000, 8001, 6060
+	// and 8003. Each container must set an image.
+	//
+	// +optional
+	ExtraContainers []corev1.Container `json:"extraContainers,omitempty"`
+
+	// ExtraInitContainers are init containers added to Contour pods. Names
+	// must not collide with the "contour" container managed by the
+	// operator.
+	//
+	// +optional
+	ExtraInitContainers []corev1.Container `json:"extraInitContainers,omitempty"`
+
+	// Strategy is the update strategy of the Contour Deployment, e.g. to
+	// roll out one pod at a time in constrained clusters.
+	//
+	// If unset, Contour pods are replaced by a rolling update with a max
+	// surge of 50% and at most 25% of the pods unavailable.
+	//
+	// +optional
+	Strategy *appsv1.DeploymentStrategy `json:"strategy,omitempty"`
+
+	// ProgressDeadlineSeconds is the number of seconds a rollout of the
+	// Contour Deployment may take to make progress before it is reported
+	// as failed.
+	//
+	// If unset, defaults to 600.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	ProgressDeadlineSeconds *int32 `json:"progressDeadlineSeconds,omitempty"`
+
+	// LivenessProbe tunes the liveness probe of the Contour container.
+	//
+	// +optional
+	LivenessProbe *ProbeSettings `json:"livenessProbe,omitempty"`
+
+	// ReadinessProbe tunes the readiness probe of the Contour container.
+	//
+	// +optional
+	ReadinessProbe *ProbeSettings `json:"readinessProbe,omitempty"`
+
+	// MetricsTLS serves Contour's metrics endpoint over TLS. While enabled,
+	// the health endpoint is served on its own plaintext port so that
+	// kubelet probes keep working.
+	//
+	// +optional
+	MetricsTLS *MetricsTLS `json:"metricsTLS,omitempty"`
+}
+
+// ProbeSettings defines the timing of a container probe, e.g. to avoid
+// restart loops on CPU-throttled nodes. Unset fields keep the defaults of
+// the probe.
+type ProbeSettings struct {
+	// InitialDelaySeconds is the number of seconds after the container
+	// starts before the probe is first run.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	InitialDelaySeconds *int32 `json:"initialDelaySeconds,omitempty"`
+
+	// TimeoutSeconds is the number of seconds after which the probe times
+	// out.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+
+	// PeriodSeconds is how often, in seconds, the probe is run.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	PeriodSeconds *int32 `json:"periodSeconds,omitempty"`
+
+	// FailureThreshold is the number of consecutive failures after which
+	// the probe is considered failed.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	FailureThreshold *int32 `json:"failureThreshold,omitempty"`
+}
+
+// MetricsTLS defines the TLS settings of a metrics endpoint.
+type MetricsTLS struct {
+	// SecretName is the name of a Secret in the Contour namespace holding
+	// the serving certificate and key in the "tls.crt" and "tls.key" keys.
+	//
+	// If unset, the certificate the operator generates for Contour is used.
+	// It's valid for the server name "contour" and signed by the CA in the
+	// "ca.crt" key of the "contourcert" Secret.
+	//
+	// +kubebuilder:validation:MaxLength=253
+	// +optional
+	SecretName string `json:"secretName,omitempty"`
+
+	// ClientCertificateValidation requires scrapers to present a client
+	// certificate signed by the CA in the "ca.crt" key of the Secret.
+	//
+	// +optional
+	ClientCertificateValidation bool `json:"clientCertificateValidation,omitempty"`
+}
+
+// EnvoyBootstrapSettings defines how Envoy's bootstrap configuration is
+// generated.
+type EnvoyBootstrapSettings struct {
+	// XDSAddress is the hostname or IP address Envoy connects to for xDS,
+	// e.g. the fully qualified name of the Contour Service in clusters with
+	// custom DNS search domains.
+	//
+	// If unset, defaults to "contour", the name of the Contour Service.
+	//
+	// +kubebuilder:validation:MaxLength=253
+	// +optional
+	XDSAddress string `json:"xdsAddress,omitempty"`
+
+	// XDSPort is the port Envoy connects to for xDS.
+	//
+	// If unset, defaults to 8001, the xDS port of the Contour Service.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	XDSPort *int32 `json:"xdsPort,omitempty"`
+}
+
+// EnvoyConcurrency defines the number of Envoy worker threads. Exactly one
+// of Workers and FromCPULimit must be set.
+type EnvoyConcurrency struct {
+	// Workers is the number of Envoy worker threads.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Workers *int32 `json:"workers,omitempty"`
+
+	// FromCPULimit sets the number of Envoy worker threads to the CPU limit
+	// of the Envoy container, rounded up. Requires
+	// spec.resources.envoy.limits.cpu to be set.
+	//
+	// +optional
+	FromCPULimit bool `json:"fromCPULimit,omitempty"`
+}
+
+// EnvoyShutdownSettings defines how Envoy is drained before its pods
+// terminate. Envoy pods are killed once terminationGracePeriodSeconds
+// elapse, regardless of these settings.
+type EnvoyShutdownSettings struct {
+	// DrainDelay is the time to wait before draining Envoy connections,
+	// e.g. to let load balancers deregister the pod first.
+	//
+	// If unset, defaults to 0s.
+	//
+	// +optional
+	DrainDelay *metav1.Duration `json:"drainDelay,omitempty"`
+
+	// CheckDelay is the time to wait after draining starts before polling
+	// Envoy for open connections.
+	//
+	// If unset, defaults to 0s.
+	//
+	// +optional
+	CheckDelay *metav1.Duration `json:"checkDelay,omitempty"`
+
+	// CheckInterval is the interval at which Envoy is polled for open
+	// connections.
+	//
+	// If unset, defaults to 5s.
+	//
+	// +optional
+	CheckInterval *metav1.Duration `json:"checkInterval,omitempty"`
+
+	// MinOpenConnections is the number of open connections below which
+	// Envoy is considered drained.
+	//
+	// If unset, defaults to 0.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MinOpenConnections *int32 `json:"minOpenConnections,omitempty"`
+}
+
+// PodDisruptionBudgetSettings defines the PodDisruptionBudget of a workload.
+// At most one of MinAvailable and MaxUnavailable may be set.
+type PodDisruptionBudgetSettings struct {
+	// MinAvailable is the number or percentage of pods that must remain
+	// available during voluntary disruptions, e.g. node drains.
+	//
+	// +optional
+	MinAvailable *intstr.IntOrString `json:"minAvailable,omitempty"`
+
+	// MaxUnavailable is the number or percentage of pods that may be
+	// unavailable during voluntary disruptions, e.g. node drains.
+	//
+	// If unset and MinAvailable is unset, defaults to 1.
+	//
+	// +optional
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+}
+
+// EnvoyWorkloadType is the type of workload used to run Envoy.
+//
+// +kubebuilder:validation:Enum=DaemonSet;Deployment
+type EnvoyWorkloadType string
+
+const (
+	DaemonSetEnvoyWorkload  EnvoyWorkloadType = "DaemonSet"
+	DeploymentEnvoyWorkload EnvoyWorkloadType = "Deployment"
+)
+
+// EnvoyNetworkType is how Envoy pods are exposed on the network.
+//
+// +kubebuilder:validation:Enum=ClusterIP;HostPorts;HostNetwork
+type EnvoyNetworkType string
+
+const (
+	ClusterIPEnvoyNetwork   EnvoyNetworkType = "ClusterIP"
+	HostPortsEnvoyNetwork   EnvoyNetworkType = "HostPorts"
+	HostNetworkEnvoyNetwork EnvoyNetworkType = "HostNetwork"
+)
+
+// EnvoyAutoscaling defines the horizontal autoscaling of Envoy.
+type EnvoyAutoscaling struct {
+	// MinReplicas is the lower limit for the number of Envoy replicas.
+	//
+	// If unset, defaults to 1.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+
+	// MaxReplicas is the upper limit for the number of Envoy replicas.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Required
+	MaxReplicas int32 `json:"maxReplicas"`
+
+	// TargetCPUUtilizationPercentage is the target average CPU utilization
+	// of Envoy pods, as a percentage of their requested CPU.
+	//
+	// If unset and no other metrics are specified, defaults to 80.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	TargetCPUUtilizationPercentage *int32 `json:"targetCPUUtilizationPercentage,omitempty"`
+
+	// TargetMemoryUtilizationPercentage is the target average memory
+	// utilization of Envoy pods, as a percentage of their requested memory.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	TargetMemoryUtilizationPercentage *int32 `json:"targetMemoryUtilizationPercentage,omitempty"`
+
+	// Metrics are additional metrics used to compute the desired number of
+	// Envoy replicas, e.g. custom or external metrics.
+	//
+	// +optional
+	Metrics []autoscalingv2.MetricSpec `json:"metrics,omitempty"`
+}
+
+// EnvoyNetworkSettings defines the network settings of Envoy.
+type EnvoyNetworkSettings struct {
+	// NumTrustedHops is the number of additional ingress proxy hops from the
+	// right side of the x-forwarded-for HTTP header to trust when determining
+	// the origin client's IP address. Set this when Envoy runs behind other
+	// L7 proxies or load balancers that append to x-forwarded-for.
+	//
+	// If unset, defaults to 0.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	NumTrustedHops *int32 `json:"numTrustedHops,omitempty"`
+}
+
+// EnvoyTLS defines the TLS settings of Envoy listeners.
+type EnvoyTLS struct {
+	// MinimumProtocolVersion is the minimum TLS version Envoy negotiates
+	// with clients. Allowed values are "1.2" and "1.3".
+	//
+	// If unset, defaults to "1.2".
+	//
+	// +optional
+	MinimumProtocolVersion TLSProtocolVersion `json:"minimumProtocolVersion,omitempty"`
+
+	// CipherSuites are the cipher suites Envoy negotiates with clients over
+	// TLS 1.2. TLS 1.3 cipher suites are not configurable. Entries use
+	// OpenSSL names, e.g. "ECDHE-RSA-AES256-GCM-SHA384", and may be
+	// equal-preference groups of the form "[CIPHER-A|CIPHER-B]". Only
+	// cipher suites supported by Contour are allowed.
+	//
+	// If unset, Contour's default cipher suites are used.
+	//
+	// +optional
+	CipherSuites []string `json:"cipherSuites,omitempty"`
+
+	// ClientCertificate is a reference to a Secret holding the client
+	// certificate and key, in the "tls.crt" and "tls.key" keys, that Envoy
+	// presents to upstream services requesting one, enabling mutual TLS
+	// between Envoy and backends.
+	//
+	// If unset, Envoy presents no client certificate.
+	//
+	// +optional
+	ClientCertificate *SecretReference `json:"clientCertificate,omitempty"`
+}
+
+// SecretReference is a reference to a Secret.
+type SecretReference struct {
+	// Name is the name of the Secret.
+	//
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Namespace is the namespace of the Secret.
+	//
+	// If unset, defaults to spec.namespace.name.
+	//
+	// +kubebuilder:validation:MaxLength=63
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// TLSProtocolVersion is a TLS protocol version.
+//
+// +kubebuilder:validation:Enum="1.2";"1.3"
+type TLSProtocolVersion string
+
+const (
+	TLSProtocolVersion1_2 TLSProtocolVersion = "1.2"
+	TLSProtocolVersion1_3 TLSProtocolVersion = "1.3"
+)
+
+// GlobalHeadersPolicy defines headers Envoy sets on or removes from all
+// proxied requests and responses.
+type GlobalHeadersPolicy struct {
+	// Request defines the headers set on or removed from requests before
+	// they are proxied to upstream services.
+	//
+	// +optional
+	Request *HeadersPolicy `json:"request,omitempty"`
+
+	// Response defines the headers set on or removed from responses before
+	// they are returned to clients.
+	//
+	// +optional
+	Response *HeadersPolicy `json:"response,omitempty"`
+
+	// ApplyToIngress also applies the policy to Ingresses. By default it
+	// only applies to HTTPProxies.
+	//
+	// +optional
+	ApplyToIngress bool `json:"applyToIngress,omitempty"`
+}
+
+// HeadersPolicy defines headers to set or remove.
+type HeadersPolicy struct {
+	// Set are headers to set, keyed by header name. Existing headers of the
+	// same name are replaced.
+	//
+	// +optional
+	Set map[string]string `json:"set,omitempty"`
+
+	// Remove are the names of headers to remove.
+	//
+	// +optional
+	Remove []string `json:"remove,omitempty"`
+}
+
+// RateLimitServiceSettings defines the global rate limit service used by
+// Envoy.
+type RateLimitServiceSettings struct {
+	// ExtensionService is a reference to the ExtensionService of the rate
+	// limit service, e.g. an Envoy rate limit service deployment.
+	//
+	// +kubebuilder:validation:Required
+	ExtensionService ExtensionServiceReference `json:"extensionService"`
+
+	// Domain is the rate limit domain passed to the rate limit service.
+	//
+	// If unset, Contour's default of "contour" is used.
+	//
+	// +kubebuilder:validation:MaxLength=253
+	// +optional
+	Domain string `json:"domain,omitempty"`
 
-	// GatewayControllerName is used to determine which GatewayClass
-	// Contour reconciles. The string takes the form of
-	// "projectcontour.io/<namespace>/contour". If unset, Contour will not
-	// reconcile Gateway API resources.
+	// FailOpen allows requests when the rate limit service is unavailable
+	// or returns an error. By default such requests are denied.
 	//
-	// +kubebuilder:validation:MaxLength=253
 	// +optional
-	GatewayControllerName *string `json:"gatewayControllerName,omitempty"`
+	FailOpen bool `json:"failOpen,omitempty"`
 
-	// IngressClassName is the name of the IngressClass used by Contour. If unset,
-	// Contour will process all ingress objects without an ingress class annotation
-	// or ingress objects with an annotation matching ingress-class=contour. When
-	// specified, Contour will only process ingress objects that match the provided
-	// class.
+	// EnableXRateLimitHeaders adds the X-RateLimit headers defined by the
+	// IETF RateLimit headers draft to responses.
 	//
-	// For additional IngressClass details, refer to:
-	//   https://projectcontour.io/docs/main/config/annotations/#ingress-class
+	// +optional
+	EnableXRateLimitHeaders bool `json:"enableXRateLimitHeaders,omitempty"`
+}
+
+// ExtensionServiceReference is a reference to a Contour ExtensionService.
+type ExtensionServiceReference struct {
+	// Name is the name of the ExtensionService.
 	//
 	// +kubebuilder:validation:MinLength=1
 	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Namespace is the namespace of the ExtensionService.
+	//
+	// If unset, defaults to spec.namespace.name.
+	//
+	// +kubebuilder:validation:MaxLength=63
 	// +optional
-	IngressClassName *string `json:"ingressClassName,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+}
 
-	// NodePlacement enables scheduling of Contour and Envoy pods onto specific nodes.
+// EnvoyAccessLog defines the format and verbosity of Envoy access logs.
+type EnvoyAccessLog struct {
+	// Format is the format of Envoy access logs. Allowed values are "envoy",
+	// Envoy's default text format, and "json", which logs the fields given
+	// by jsonFields.
 	//
-	// See each field for additional details.
+	// If unset, defaults to "envoy".
 	//
 	// +optional
-	NodePlacement *NodePlacement `json:"nodePlacement,omitempty"`
+	Format AccessLogFormat `json:"format,omitempty"`
 
-	// EnableExternalNameService enables ExternalName Services.
-	// ExternalName Services are disabled by default due to CVE-2021-XXXXX
-	// You can re-enable them by setting this setting to "true".
-	// This is not recommended without understanding the security implications.
-	// Please see the advisory at https://github.com/projectcontour/contour/security/advisories/GHSA-5ph6-qq5x-7jwc for the details.
+	// JSONFields are the fields logged when format is "json". Entries are
+	// either names of fields known to Contour, e.g. "@timestamp" or
+	// "response_code", or custom fields of the form
+	// "name=%ENVOY_COMMAND_OPERATOR%". The canonical list of known fields is
+	// available at https://godoc.org/github.com/projectcontour/contour/internal/envoy#JSONFields.
+	//
+	// If unset, Contour's default fields are logged.
 	//
 	// +optional
-	EnableExternalNameService *bool `json:"enableExternalNameService,omitempty"`
+	JSONFields []string `json:"jsonFields,omitempty"`
+
+	// Level is the verbosity of Envoy access logs. Allowed values are "info",
+	// which logs all requests, "error", which only logs requests that
+	// failed, i.e. with a 4xx/5xx response code or an upstream failure, and
+	// "disabled", which disables access logging.
+	//
+	// If unset, defaults to "info".
+	//
+	// +optional
+	Level AccessLogLevel `json:"level,omitempty"`
 }
 
-// NodePlacement describes node scheduling configuration of Contour and Envoy pods.
-type NodePlacement struct {
-	// Contour describes node scheduling configuration of Contour pods.
+// AccessLogFormat is the format of Envoy access logs.
+//
+// +kubebuilder:validation:Enum=envoy;json
+type AccessLogFormat string
+
+const (
+	EnvoyAccessLogFormat AccessLogFormat = "envoy"
+	JSONAccessLogFormat  AccessLogFormat = "json"
+)
+
+// AccessLogLevel is the verbosity of Envoy access logs.
+//
+// +kubebuilder:validation:Enum=info;error;disabled
+type AccessLogLevel string
+
+const (
+	InfoAccessLogLevel     AccessLogLevel = "info"
+	ErrorAccessLogLevel    AccessLogLevel = "error"
+	DisabledAccessLogLevel AccessLogLevel = "disabled"
+)
+
+// HTTPVersionType is an HTTP version offered by Envoy to clients.
+//
+// +kubebuilder:validation:Enum="HTTP/1.1";"HTTP/2"
+type HTTPVersionType string
+
+const (
+	HTTPVersion1 HTTPVersionType = "HTTP/1.1"
+	HTTPVersion2 HTTPVersionType = "HTTP/2"
+)
+
+// EnvoyTimeouts defines the timeouts Envoy applies to client connections and
+// requests. Each value is a duration, e.g. "30s" or "1m30s", or "infinity" to
+// disable the timeout. Unset timeouts use Contour's defaults.
+type EnvoyTimeouts struct {
+	// RequestTimeout is the timeout for an entire request, from the first
+	// byte received from the client to the last byte of the response.
+	//
+	// If unset, defaults to "infinity".
 	//
 	// +optional
-	Contour *ContourNodePlacement `json:"contour,omitempty"`
+	RequestTimeout string `json:"requestTimeout,omitempty"`
 
-	// Envoy describes node scheduling configuration of Envoy pods.
+	// ConnectionIdleTimeout is the time a client connection may remain open
+	// without active requests before Envoy closes it.
+	//
+	// If unset, defaults to "60s".
 	//
 	// +optional
-	Envoy *EnvoyNodePlacement `json:"envoy,omitempty"`
-}
+	ConnectionIdleTimeout string `json:"connectionIdleTimeout,omitempty"`
 
-// ContourNodePlacement describes node scheduling configuration for Contour pods.
-// If nodeSelector and tolerations are specified, the scheduler will use both to
-// determine where to place the Contour pod(s).
-type ContourNodePlacement struct {
-	// NodeSelector is the simplest recommended form of node selection constraint
-	// and specifies a map of key-value pairs. For the Contour pod to be eligible
-	// to run on a node, the node must have each of the indicated key-value pairs
-	// as labels (it can have additional labels as well).
+	// StreamIdleTimeout is the time a request stream may remain without
+	// activity before Envoy resets it.
 	//
-	// If unset, the Contour pod(s) will be scheduled to any available node.
+	// If unset, defaults to "5m".
 	//
 	// +optional
-	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+	StreamIdleTimeout string `json:"streamIdleTimeout,omitempty"`
 
-	// Tolerations work with taints to ensure that Envoy pods are not scheduled
-	// onto inappropriate nodes. One or more taints are applied to a node; this
-	// marks that the node should not accept any pods that do not tolerate the
-	// taints.
+	// MaxConnectionDuration is the maximum time a client connection may
+	// remain open, regardless of activity.
 	//
-	// The default is an empty list.
+	// If unset, defaults to "infinity".
 	//
-	// See https://kubernetes.io/docs/concepts/configuration/taint-and-toleration/
-	// for additional details.
+	// +optional
+	MaxConnectionDuration string `json:"maxConnectionDuration,omitempty"`
+
+	// ConnectionShutdownGracePeriod is the time Envoy waits between sending
+	// the initial and final GOAWAY frames when closing an HTTP/2 connection.
+	//
+	// If unset, defaults to "5s".
 	//
 	// +optional
-	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+	ConnectionShutdownGracePeriod string `json:"connectionShutdownGracePeriod,omitempty"`
 }
 
-// EnvoyNodePlacement describes node scheduling configuration for Envoy pods.
-// If nodeSelector and tolerations are specified, the scheduler will use both
-// to determine where to place the Envoy pod(s).
-type EnvoyNodePlacement struct {
-	// NodeSelector is the simplest recommended form of node selection constraint
-	// and specifies a map of key-value pairs. For the Envoy pod to be eligible to
-	// run on a node, the node must have each of the indicated key-value pairs as
-	// labels (it can have additional labels as well).
+// ServerHeaderTransformationType defines how Envoy handles the Server header
+// of HTTP responses.
+//
+// +kubebuilder:validation:Enum=overwrite;append_if_absent;pass_through
+type ServerHeaderTransformationType string
+
+const (
+	OverwriteServerHeader      ServerHeaderTransformationType = "overwrite"
+	AppendIfAbsentServerHeader ServerHeaderTransformationType = "append_if_absent"
+	PassThroughServerHeader    ServerHeaderTransformationType = "pass_through"
+)
+
+// EnvoyCompression defines the compression applied by Envoy to HTTP responses.
+type EnvoyCompression struct {
+	// Algorithm is the compression algorithm used for HTTP responses. Allowed
+	// values are "gzip", "brotli", "zstd" and "disabled".
 	//
-	// If unset, the Envoy pod(s) will be scheduled to any available node.
+	// If unset, defaults to "gzip".
 	//
 	// +optional
-	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+	Algorithm CompressionAlgorithm `json:"algorithm,omitempty"`
+}
 
-	// Tolerations work with taints to ensure that Envoy pods are not scheduled
-	// onto inappropriate nodes. One or more taints are applied to a node; this
-	// marks that the node should not accept any pods that do not tolerate the taints.
+// CompressionAlgorithm is the compression algorithm used by Envoy.
+//
+// +kubebuilder:validation:Enum=gzip;brotli;zstd;disabled
+type CompressionAlgorithm string
+
+const (
+	GzipCompression     CompressionAlgorithm = "gzip"
+	BrotliCompression   CompressionAlgorithm = "brotli"
+	ZstdCompression     CompressionAlgorithm = "zstd"
+	DisabledCompression CompressionAlgorithm = "disabled"
+)
+
+// EnvoyListenerSettings defines the settings of Envoy listeners.
+type EnvoyListenerSettings struct {
+	// PerConnectionBufferLimitBytes is the soft limit, in bytes, on the size of
+	// the read and write buffers of each downstream connection.
 	//
-	// The default is an empty list.
+	// If unset, Envoy's default of 1MiB is used.
 	//
-	// See https://kubernetes.io/docs/concepts/configuration/taint-and-toleration/
-	// for additional details.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	PerConnectionBufferLimitBytes *int32 `json:"perConnectionBufferLimitBytes,omitempty"`
+
+	// HTTP2MaxConcurrentStreams is the maximum number of concurrent streams
+	// Envoy allows on each downstream HTTP/2 connection. Raise it for
+	// clients that multiplex many long-lived streams, e.g. gRPC streaming.
+	//
+	// If unset, Envoy's default of 2147483647 is used.
 	//
+	// +kubebuilder:validation:Minimum=1
 	// +optional
-	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+	HTTP2MaxConcurrentStreams *int32 `json:"http2MaxConcurrentStreams,omitempty"`
+}
+
+// EnvoyClusterSettings defines the settings of Envoy clusters.
+type EnvoyClusterSettings struct {
+	// PerConnectionBufferLimitBytes is the soft limit, in bytes, on the size of
+	// the read and write buffers of each upstream connection.
+	//
+	// If unset, Envoy's default of 1MiB is used.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	PerConnectionBufferLimitBytes *int32 `json:"perConnectionBufferLimitBytes,omitempty"`
 }
 
 // NamespaceSpec defines the schema of a Contour namespace.
@@ -217,6 +1443,21 @@ type NamespaceSpec struct {
 	//
 	// +kubebuilder:default=false
 	RemoveOnDeletion bool `json:"removeOnDeletion,omitempty"`
+
+	// Labels are added to the namespace, e.g. Pod Security Admission or
+	// service mesh injection labels. Labels are only applied to namespaces
+	// created by the operator and the Contour owner labels take precedence.
+	// Labels removed from this field are removed from the namespace.
+	//
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Annotations are added to the namespace. Annotations are only applied
+	// to namespaces created by the operator. Annotations removed from this
+	// field are removed from the namespace.
+	//
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
 }
 
 // NetworkPublishing defines the schema for publishing Contour to a network.
@@ -233,6 +1474,29 @@ type NetworkPublishing struct {
 	//
 	// +kubebuilder:default={type: LoadBalancerService, loadBalancer: {scope: External, providerParameters: {type: AWS}}, containerPorts: {{name: http, portNumber: 8080}, {name: https, portNumber: 8443}}}
 	Envoy EnvoyNetworkPublishing `json:"envoy,omitempty"`
+
+	// IPFamilyPolicy is the dual-stack policy of the Contour and Envoy Services.
+	// Allowed values are "SingleStack", "PreferDualStack" and "RequireDualStack".
+	//
+	// If unset, the cluster default is used, which is "SingleStack" unless the
+	// Services are headless.
+	//
+	// See: https://kubernetes.io/docs/concepts/services-networking/dual-stack/#services
+	//
+	// +kubebuilder:validation:Enum=SingleStack;PreferDualStack;RequireDualStack
+	// +optional
+	IPFamilyPolicy *corev1.IPFamilyPolicyType `json:"ipFamilyPolicy,omitempty"`
+
+	// IPFamilies is the list of IP families, "IPv4" and/or "IPv6", assigned to
+	// the Contour and Envoy Services. The first family is the primary family
+	// of the Services and cannot be changed once the Services exist.
+	//
+	// If unset, the IP families are chosen by the cluster based on
+	// ipFamilyPolicy.
+	//
+	// +kubebuilder:validation:MaxItems=2
+	// +optional
+	IPFamilies []corev1.IPFamily `json:"ipFamilies,omitempty"`
 }
 
 // EnvoyNetworkPublishing defines the schema to publish Envoy to a network.
@@ -244,8 +1508,9 @@ type EnvoyNetworkPublishing struct {
 	//
 	// In this configuration, network endpoints for Envoy use container networking.
 	// A Kubernetes LoadBalancer Service is created to publish Envoy network
-	// endpoints. The Service uses port 80 to publish Envoy's HTTP network endpoint
-	// and port 443 to publish Envoy's HTTPS network endpoint.
+	// endpoints. By default, the Service uses port 80 to publish Envoy's HTTP network
+	// endpoint and port 443 to publish Envoy's HTTPS network endpoint. See servicePorts
+	// for using other port numbers.
 	//
 	// See: https://kubernetes.io/docs/concepts/services-networking/service/#loadbalancer
 	//
@@ -320,6 +1585,59 @@ type EnvoyNetworkPublishing struct {
 	// +kubebuilder:validation:MaxItems=2
 	// +kubebuilder:default={{name: http, portNumber: 8080}, {name: https, portNumber: 8443}}
 	ContainerPorts []ContainerPort `json:"containerPorts,omitempty"`
+
+	// ServicePorts is a list of ports exposed by the Envoy Service. Names and port
+	// numbers must be unique in the list. Ports must be named "http" for Envoy's
+	// insecure service or "https" for Envoy's secure service.
+	//
+	// If a port is unspecified, the "http" port defaults to 80 and the "https"
+	// port defaults to 443.
+	//
+	// +kubebuilder:validation:MaxItems=2
+	// +optional
+	ServicePorts []ServicePort `json:"servicePorts,omitempty"`
+
+	// ExtraPorts is a list of additional TCP ports exposed by the Envoy Service
+	// and the Envoy container(s), e.g. for TLS passthrough or TCP proxying on
+	// ports other than the http and https ports. Names, service port numbers
+	// and container port numbers must be unique and must not conflict with the
+	// http and https ports.
+	//
+	// +kubebuilder:validation:MaxItems=16
+	// +optional
+	ExtraPorts []ExtraPort `json:"extraPorts,omitempty"`
+
+	// Hostname is the DNS name, e.g. "ingress.example.com", that external-dns
+	// should publish for the Envoy Service. When set, the Envoy Service is
+	// annotated with "external-dns.alpha.kubernetes.io/hostname" and the
+	// hostname is reported in status once the load balancer is provisioned.
+	//
+	// See: https://github.com/kubernetes-sigs/external-dns
+	//
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	// +optional
+	Hostname *string `json:"hostname,omitempty"`
+
+	// SessionAffinity is the session affinity of the Envoy Service. Set to
+	// "ClientIP" to route connections from a client to the same Envoy pod.
+	//
+	// If unset, defaults to "None".
+	//
+	// See: https://kubernetes.io/docs/concepts/services-networking/service/#session-stickiness
+	//
+	// +kubebuilder:validation:Enum=None;ClientIP
+	// +optional
+	SessionAffinity corev1.ServiceAffinity `json:"sessionAffinity,omitempty"`
+
+	// SessionAffinityConfig contains the configuration of session affinity.
+	// Present only if sessionAffinity is ClientIP.
+	//
+	// If unset and sessionAffinity is ClientIP, the client IP timeout defaults
+	// to 10800 seconds (3 hours).
+	//
+	// +optional
+	SessionAffinityConfig *corev1.SessionAffinityConfig `json:"sessionAffinityConfig,omitempty"`
 }
 
 // NetworkPublishingType is a way to publish network endpoints.
@@ -353,6 +1671,62 @@ type LoadBalancerStrategy struct {
 	//
 	// +kubebuilder:default={type: "AWS"}
 	ProviderParameters ProviderLoadBalancerParameters `json:"providerParameters,omitempty"`
+
+	// ProxyProtocol enables the PROXY protocol between the load balancer and
+	// Envoy so that client IP addresses are preserved through L4 load balancers.
+	// When enabled, Contour configures all Envoy listeners to expect the PROXY
+	// protocol and, for AWS load balancers, the Envoy Service is annotated with
+	// "service.beta.kubernetes.io/aws-load-balancer-proxy-protocol".
+	//
+	// If unset, defaults to true for AWS Classic load balancers and false for
+	// all other load balancers. GCP and MetalLB load balancers don't send the
+	// PROXY protocol header, so enabling it for them requires LoadBalancerClass
+	// to be set.
+	//
+	// +optional
+	ProxyProtocol *bool `json:"proxyProtocol,omitempty"`
+
+	// LoadBalancerClass is the class of the load balancer implementation the
+	// Envoy Service belongs to, e.g. "service.k8s.aws/nlb". When set, the
+	// cloud provider's default load balancer controller ignores the Service
+	// and the controller for the class provisions the load balancer instead.
+	// The class of a Service can't be changed in place. Changing it sets
+	// the contour's EnvoyServiceSynced condition to false until the Envoy
+	// Service is deleted, after which the operator recreates it with the
+	// new class. Recreating the Service replaces its load balancer and
+	// address, so the operator never deletes it on its own.
+	//
+	// If unset, the cluster's default load balancer implementation is used.
+	//
+	// See: https://kubernetes.io/docs/concepts/services-networking/service/#load-balancer-class
+	//
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	// +optional
+	LoadBalancerClass *string `json:"loadBalancerClass,omitempty"`
+
+	// AllocateLoadBalancerNodePorts defines whether node ports are allocated
+	// for the Envoy Service. Set to false for load balancer implementations
+	// that route traffic directly to pods and don't rely on node ports.
+	//
+	// If unset, defaults to true.
+	//
+	// +optional
+	AllocateLoadBalancerNodePorts *bool `json:"allocateLoadBalancerNodePorts,omitempty"`
+
+	// HealthCheckNodePort is the node port the load balancer uses to health
+	// check Envoy nodes. Since the Envoy Service uses the "Local" external
+	// traffic policy, only nodes running an Envoy pod pass the health check.
+	// Pinning the port allows external load balancer health checks to be
+	// configured before the Service is created.
+	//
+	// If unset, a port is assigned by the Kubernetes API server and preserved
+	// across updates of the Service.
+	//
+	// +kubebuilder:validation:Minimum=30000
+	// +kubebuilder:validation:Maximum=32767
+	// +optional
+	HealthCheckNodePort *int32 `json:"healthCheckNodePort,omitempty"`
 }
 
 // LoadBalancerScope is the scope at which a load balancer is exposed.
@@ -375,7 +1749,7 @@ var (
 // +union
 type ProviderLoadBalancerParameters struct {
 	// Type is the underlying infrastructure provider for the load balancer.
-	// Allowed values are "AWS", "Azure", and "GCP".
+	// Allowed values are "AWS", "Azure", "GCP" and "MetalLB".
 	//
 	// +unionDiscriminator
 	// +kubebuilder:default=AWS
@@ -407,18 +1781,27 @@ type ProviderLoadBalancerParameters struct {
 	//
 	// +optional
 	GCP *GCPLoadBalancerParameters `json:"gcp,omitempty"`
+
+	// MetalLB provides configuration settings that are specific to MetalLB
+	// load balancers on bare-metal clusters.
+	//
+	// If empty, MetalLB assigns an address from any of its address pools.
+	//
+	// +optional
+	MetalLB *MetalLBLoadBalancerParameters `json:"metalLB,omitempty"`
 }
 
 // LoadBalancerProviderType is the underlying infrastructure provider for the
-// load balancer. Allowed values are "AWS", "Azure", and "GCP".
+// load balancer. Allowed values are "AWS", "Azure", "GCP" and "MetalLB".
 //
-// +kubebuilder:validation:Enum=AWS;Azure;GCP
+// +kubebuilder:validation:Enum=AWS;Azure;GCP;MetalLB
 type LoadBalancerProviderType string
 
 const (
-	AWSLoadBalancerProvider   LoadBalancerProviderType = "AWS"
-	AzureLoadBalancerProvider LoadBalancerProviderType = "Azure"
-	GCPLoadBalancerProvider   LoadBalancerProviderType = "GCP"
+	AWSLoadBalancerProvider     LoadBalancerProviderType = "AWS"
+	AzureLoadBalancerProvider   LoadBalancerProviderType = "Azure"
+	GCPLoadBalancerProvider     LoadBalancerProviderType = "GCP"
+	MetalLBLoadBalancerProvider LoadBalancerProviderType = "MetalLB"
 )
 
 // AWSLoadBalancerParameters provides configuration settings that are specific to
@@ -505,6 +1888,18 @@ type AzureLoadBalancerParameters struct {
 	// +kubebuilder:validation:MaxLength=80
 	// +optional
 	Subnet *string `json:"subnet,omitempty"`
+
+	// PublicIPName is the name of an existing public IP resource to assign
+	// to the load balancer. Relevant only if scope is "External". Referencing
+	// the public IP by name keeps the load balancer address stable when the
+	// Envoy Service is re-created.
+	//
+	// See: https://docs.microsoft.com/en-us/azure/aks/static-ip#create-a-service-using-the-static-ip-address
+	//
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=80
+	// +optional
+	PublicIPName *string `json:"publicIPName,omitempty"`
 }
 
 type GCPLoadBalancerParameters struct {
@@ -532,6 +1927,58 @@ type GCPLoadBalancerParameters struct {
 	// +kubebuilder:validation:MaxLength=63
 	// +optional
 	Subnet *string `json:"subnet,omitempty"`
+
+	// NetworkEndpointGroups configures GKE network endpoint groups (NEGs) for
+	// the Envoy Service, enabling container-native load balancing to Envoy
+	// pods. When set, the Envoy Service is annotated with "cloud.google.com/neg".
+	//
+	// See: https://cloud.google.com/kubernetes-engine/docs/how-to/container-native-load-balancing
+	//
+	// +optional
+	NetworkEndpointGroups *GCPNetworkEndpointGroups `json:"networkEndpointGroups,omitempty"`
+}
+
+// GCPNetworkEndpointGroups configures GKE network endpoint groups for the
+// Envoy Service.
+type GCPNetworkEndpointGroups struct {
+	// Ingress enables NEGs for use by GKE Ingress load balancers.
+	//
+	// +optional
+	Ingress bool `json:"ingress,omitempty"`
+
+	// ExposedPorts is a list of Envoy Service port numbers to create
+	// standalone NEGs for, e.g. for use with manually configured Google
+	// Cloud load balancers.
+	//
+	// See: https://cloud.google.com/kubernetes-engine/docs/how-to/standalone-neg
+	//
+	// +kubebuilder:validation:MaxItems=18
+	// +optional
+	ExposedPorts []int32 `json:"exposedPorts,omitempty"`
+}
+
+// MetalLBLoadBalancerParameters provides configuration settings that are
+// specific to MetalLB load balancers.
+type MetalLBLoadBalancerParameters struct {
+	// AddressPool is the name of the MetalLB address pool to assign the
+	// load balancer address from.
+	//
+	// See: https://metallb.universe.tf/usage/#requesting-specific-ips
+	//
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	// +optional
+	AddressPool *string `json:"addressPool,omitempty"`
+
+	// AllowSharedIP is the sharing key that allows the Envoy Service to share
+	// its load balancer address with other Services using the same key.
+	//
+	// See: https://metallb.universe.tf/usage/#ip-address-sharing
+	//
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	// +optional
+	AllowSharedIP *string `json:"allowSharedIP,omitempty"`
 }
 
 // NodePort is the schema to specify a network port for a NodePort Service.
@@ -577,10 +2024,61 @@ type ContainerPort struct {
 	PortNumber int32 `json:"portNumber"`
 }
 
+// ServicePort is the schema to specify a network port for the Envoy Service.
+type ServicePort struct {
+	// Name is the name of the port within the Service. Valid values are "http"
+	// and "https".
+	//
+	// +kubebuilder:validation:Enum=http;https
+	Name string `json:"name"`
+
+	// PortNumber is the network port number exposed by the Service.
+	// The number must be greater than 0 and less than 65536.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	PortNumber int32 `json:"portNumber"`
+}
+
+// ExtraPort is the schema to specify an additional network port exposed by
+// the Envoy Service and the Envoy container(s).
+type ExtraPort struct {
+	// Name is an IANA_SVC_NAME used for both the Service port and the
+	// container port. The names "http" and "https" are reserved.
+	//
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=15
+	Name string `json:"name"`
+
+	// PortNumber is the network port number exposed by the Envoy Service.
+	// The number must be greater than 0 and less than 65536.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	PortNumber int32 `json:"portNumber"`
+
+	// ContainerPortNumber is the network port number to expose on the envoy
+	// pod that the Service port targets. The number must be greater than 0
+	// and less than 65536.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	ContainerPortNumber int32 `json:"containerPortNumber"`
+}
+
 const (
 	// ContourAvailableConditionType indicates that the contour is running
 	// and available.
 	ContourAvailableConditionType = "Available"
+
+	// ContourValidConditionType indicates whether the contour passed
+	// validation. The operator doesn't reconcile an invalid contour.
+	ContourValidConditionType = "Valid"
+
+	// EnvoyServiceSyncedConditionType indicates whether the Envoy Service
+	// matches the contour. It is false when a field that can't be updated in
+	// place, e.g. the load balancer class, differs from the contour.
+	EnvoyServiceSyncedConditionType = "EnvoyServiceSynced"
 )
 
 // ContourStatus defines the observed state of Contour.
@@ -591,14 +2089,43 @@ type ContourStatus struct {
 	// the contour.
 	AvailableContours int32 `json:"availableContours"`
 
+	// Replicas is the number of observed replicas of the Contour
+	// deployment. It backs the scale subresource of the contour.
+	//
+	// +optional
+	Replicas int32 `json:"replicas,omitempty"`
+
+	// Selector is the label selector of the Contour deployment's pods,
+	// in string form. It backs the scale subresource of the contour.
+	//
+	// A HorizontalPodAutoscaler resolves the selector in its own namespace,
+	// which must be the namespace of the Contour, while the pods run in
+	// spec.namespace.name. The selector is therefore only published when
+	// the two namespaces match. In the default layout, e.g. a Contour in
+	// "contour-operator" managing pods in "projectcontour", the selector is
+	// empty and a HorizontalPodAutoscaler targeting the Contour reports
+	// that a selector is required. Autoscaling Contour is not supported in
+	// that layout; Envoy can be autoscaled with spec.envoy.autoscaling.
+	//
+	// +optional
+	Selector string `json:"selector,omitempty"`
+
 	// AvailableEnvoys is the number of observed available pods from
-	// the Envoy daemonset. The daemonset and its pods will reside in the
-	// namespace specified by spec.namespace.name of the contour.
+	// the Envoy daemonset or deployment. The workload and its pods will
+	// reside in the namespace specified by spec.namespace.name of the
+	// contour.
 	AvailableEnvoys int32 `json:"availableEnvoys"`
 
+	// Hostname is the DNS name published for the Envoy Service. It is set
+	// once spec.networkPublishing.envoy.hostname is specified and the
+	// Envoy Service's load balancer has been provisioned.
+	//
+	// +optional
+	Hostname string `json:"hostname,omitempty"`
+
 	// Conditions represent the observations of a contour's current state.
-	// Known condition types are "Available". Reference the condition type
-	// for additional details.
+	// Known condition types are "Available", "Valid" and "EnvoyServiceSynced".
+	// Reference the condition type for additional details.
 	//
 	// +patchMergeKey=type
 	// +patchStrategy=merge
@@ -13,6 +13,13 @@
 
 package v1alpha1
 
+import (
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
 const (
 	// GatewayClassControllerRef identifies contour operator as the managing controller
 	// of a GatewayClass.
@@ -81,6 +88,17 @@ func (c *Contour) ContourTolerationsExist() bool {
 	return false
 }
 
+// ContourAffinityExists returns true if affinity is set for Contour.
+func (c *Contour) ContourAffinityExists() bool {
+	if c.Spec.NodePlacement != nil &&
+		c.Spec.NodePlacement.Contour != nil &&
+		c.Spec.NodePlacement.Contour.Affinity != nil {
+		return true
+	}
+
+	return false
+}
+
 // EnvoyNodeSelectorExists returns true if a nodeSelector is specified for Envoy.
 func (c *Contour) EnvoyNodeSelectorExists() bool {
 	if c.Spec.NodePlacement != nil &&
@@ -102,3 +120,123 @@ func (c *Contour) EnvoyTolerationsExist() bool {
 
 	return false
 }
+
+// EnvoyAffinityExists returns true if affinity is set for Envoy.
+func (c *Contour) EnvoyAffinityExists() bool {
+	if c.Spec.NodePlacement != nil &&
+		c.Spec.NodePlacement.Envoy != nil &&
+		c.Spec.NodePlacement.Envoy.Affinity != nil {
+		return true
+	}
+
+	return false
+}
+
+// ProxyProtocolEnabled returns true if the PROXY protocol is used between the
+// load balancer and Envoy.
+func (c *Contour) ProxyProtocolEnabled() bool {
+	envoy := c.Spec.NetworkPublishing.Envoy
+	if envoy.Type != LoadBalancerServicePublishingType {
+		return false
+	}
+
+	if envoy.LoadBalancer.ProxyProtocol != nil {
+		return *envoy.LoadBalancer.ProxyProtocol
+	}
+
+	// The PROXY protocol is enabled by default for AWS Classic load balancers.
+	params := envoy.LoadBalancer.ProviderParameters
+	return params.Type == AWSLoadBalancerProvider &&
+		(params.AWS == nil || params.AWS.Type == AWSClassicLoadBalancer)
+}
+
+// EnvoyWorkloadType returns the type of workload used to run Envoy,
+// defaulting to DaemonSet.
+func (c *Contour) EnvoyWorkloadType() EnvoyWorkloadType {
+	if c.Spec.Envoy != nil && c.Spec.Envoy.WorkloadType != "" {
+		return c.Spec.Envoy.WorkloadType
+	}
+	return DaemonSetEnvoyWorkload
+}
+
+// ContourMetricsTLS returns the TLS settings of Contour's metrics endpoint,
+// or nil if metrics are served in plaintext.
+func (c *Contour) ContourMetricsTLS() *MetricsTLS {
+	if c.Spec.Contour == nil {
+		return nil
+	}
+	return c.Spec.Contour.MetricsTLS
+}
+
+// ContourPDBEnabled returns true if a PodDisruptionBudget protects the
+// Contour pods.
+func (c *Contour) ContourPDBEnabled() bool {
+	return c.Spec.Contour != nil && c.Spec.Contour.PodDisruptionBudget != nil
+}
+
+// EnvoyPDBEnabled returns true if a PodDisruptionBudget protects the Envoy
+// pods.
+func (c *Contour) EnvoyPDBEnabled() bool {
+	return c.EnvoyWorkloadType() == DeploymentEnvoyWorkload && c.Spec.Envoy.PodDisruptionBudget != nil
+}
+
+// EnvoyNetworkType returns how Envoy pods are exposed on the network,
+// defaulting to ClusterIP.
+func (c *Contour) EnvoyNetworkType() EnvoyNetworkType {
+	if c.Spec.Envoy != nil && c.Spec.Envoy.NetworkType != "" {
+		return c.Spec.Envoy.NetworkType
+	}
+	return ClusterIPEnvoyNetwork
+}
+
+// EnvoyAutoscalingEnabled returns true if a HorizontalPodAutoscaler manages
+// the replicas of the Envoy Deployment.
+func (c *Contour) EnvoyAutoscalingEnabled() bool {
+	return c.EnvoyWorkloadType() == DeploymentEnvoyWorkload && c.Spec.Envoy.Autoscaling != nil
+}
+
+// ContourImageOverride returns the image override of Contour, if any.
+func (c *Contour) ContourImageOverride() *ImageOverride {
+	if c.Spec.Image != nil {
+		return c.Spec.Image.Contour
+	}
+	return nil
+}
+
+// EnvoyImageOverride returns the image override of Envoy, if any.
+func (c *Contour) EnvoyImageOverride() *ImageOverride {
+	if c.Spec.Image != nil {
+		return c.Spec.Image.Envoy
+	}
+	return nil
+}
+
+// ImagePullSecrets returns the image pull secrets of pods managed for Contour.
+func (c *Contour) ImagePullSecrets() []corev1.LocalObjectReference {
+	var secrets []corev1.LocalObjectReference
+	for _, ref := range c.Spec.ImagePullSecretRefs {
+		secrets = append(secrets, corev1.LocalObjectReference{Name: ref.Name})
+	}
+	return secrets
+}
+
+// KindPaused returns true if reconciliation of child resources of the given
+// kind is paused by the paused-kinds annotation of Contour.
+func (c *Contour) KindPaused(kind string) bool {
+	for _, k := range strings.Split(c.Annotations[PausedKindsAnnotation], ",") {
+		if strings.EqualFold(strings.TrimSpace(k), kind) {
+			return true
+		}
+	}
+	return false
+}
+
+// ExpirationTime returns the time at which Contour expires and true, or false
+// if Contour does not expire.
+func (c *Contour) ExpirationTime() (time.Time, bool) {
+	if c.Spec.TTLSecondsAfterCreation == nil {
+		return time.Time{}, false
+	}
+	ttl := time.Duration(*c.Spec.TTLSecondsAfterCreation) * time.Second
+	return c.CreationTimestamp.Add(ttl), true
+}
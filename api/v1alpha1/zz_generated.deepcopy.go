@@ -19,9 +19,12 @@
 package v1alpha1
 
 import (
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/api/autoscaling/v2"
 	"k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -62,6 +65,11 @@ func (in *AzureLoadBalancerParameters) DeepCopyInto(out *AzureLoadBalancerParame
 		*out = new(string)
 		**out = **in
 	}
+	if in.PublicIPName != nil {
+		in, out := &in.PublicIPName, &out.PublicIPName
+		*out = new(string)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureLoadBalancerParameters.
@@ -74,6 +82,26 @@ func (in *AzureLoadBalancerParameters) DeepCopy() *AzureLoadBalancerParameters {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertgenSettings) DeepCopyInto(out *CertgenSettings) {
+	*out = *in
+	if in.CertificateLifetime != nil {
+		in, out := &in.CertificateLifetime, &out.CertificateLifetime
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertgenSettings.
+func (in *CertgenSettings) DeepCopy() *CertgenSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(CertgenSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ContainerPort) DeepCopyInto(out *ContainerPort) {
 	*out = *in
@@ -89,6 +117,25 @@ func (in *ContainerPort) DeepCopy() *ContainerPort {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContainerResources) DeepCopyInto(out *ContainerResources) {
+	*out = *in
+	in.Contour.DeepCopyInto(&out.Contour)
+	in.Envoy.DeepCopyInto(&out.Envoy)
+	in.ShutdownManager.DeepCopyInto(&out.ShutdownManager)
+	in.EnvoyInitConfig.DeepCopyInto(&out.EnvoyInitConfig)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerResources.
+func (in *ContainerResources) DeepCopy() *ContainerResources {
+	if in == nil {
+		return nil
+	}
+	out := new(ContainerResources)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Contour) DeepCopyInto(out *Contour) {
 	*out = *in
@@ -165,6 +212,18 @@ func (in *ContourNodePlacement) DeepCopyInto(out *ContourNodePlacement) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(v1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TopologySpreadConstraints != nil {
+		in, out := &in.TopologySpreadConstraints, &out.TopologySpreadConstraints
+		*out = make([]v1.TopologySpreadConstraint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContourNodePlacement.
@@ -177,10 +236,119 @@ func (in *ContourNodePlacement) DeepCopy() *ContourNodePlacement {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContourSettings) DeepCopyInto(out *ContourSettings) {
+	*out = *in
+	if in.PodAnnotations != nil {
+		in, out := &in.PodAnnotations, &out.PodAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.PodLabels != nil {
+		in, out := &in.PodLabels, &out.PodLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.PodSecurityContext != nil {
+		in, out := &in.PodSecurityContext, &out.PodSecurityContext
+		*out = new(v1.PodSecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecurityContext != nil {
+		in, out := &in.SecurityContext, &out.SecurityContext
+		*out = new(v1.SecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DNSConfig != nil {
+		in, out := &in.DNSConfig, &out.DNSConfig
+		*out = new(v1.PodDNSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodDisruptionBudget != nil {
+		in, out := &in.PodDisruptionBudget, &out.PodDisruptionBudget
+		*out = new(PodDisruptionBudgetSettings)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExtraVolumes != nil {
+		in, out := &in.ExtraVolumes, &out.ExtraVolumes
+		*out = make([]v1.Volume, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ExtraVolumeMounts != nil {
+		in, out := &in.ExtraVolumeMounts, &out.ExtraVolumeMounts
+		*out = make([]v1.VolumeMount, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ExtraEnv != nil {
+		in, out := &in.ExtraEnv, &out.ExtraEnv
+		*out = make([]v1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ExtraContainers != nil {
+		in, out := &in.ExtraContainers, &out.ExtraContainers
+		*out = make([]v1.Container, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ExtraInitContainers != nil {
+		in, out := &in.ExtraInitContainers, &out.ExtraInitContainers
+		*out = make([]v1.Container, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Strategy != nil {
+		in, out := &in.Strategy, &out.Strategy
+		*out = new(appsv1.DeploymentStrategy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ProgressDeadlineSeconds != nil {
+		in, out := &in.ProgressDeadlineSeconds, &out.ProgressDeadlineSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.LivenessProbe != nil {
+		in, out := &in.LivenessProbe, &out.LivenessProbe
+		*out = new(ProbeSettings)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ReadinessProbe != nil {
+		in, out := &in.ReadinessProbe, &out.ReadinessProbe
+		*out = new(ProbeSettings)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MetricsTLS != nil {
+		in, out := &in.MetricsTLS, &out.MetricsTLS
+		*out = new(MetricsTLS)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContourSettings.
+func (in *ContourSettings) DeepCopy() *ContourSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(ContourSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ContourSpec) DeepCopyInto(out *ContourSpec) {
 	*out = *in
-	out.Namespace = in.Namespace
+	in.Namespace.DeepCopyInto(&out.Namespace)
 	in.NetworkPublishing.DeepCopyInto(&out.NetworkPublishing)
 	if in.GatewayClassRef != nil {
 		in, out := &in.GatewayClassRef, &out.GatewayClassRef
@@ -197,16 +365,66 @@ func (in *ContourSpec) DeepCopyInto(out *ContourSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.RootNamespaces != nil {
+		in, out := &in.RootNamespaces, &out.RootNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.NodePlacement != nil {
 		in, out := &in.NodePlacement, &out.NodePlacement
 		*out = new(NodePlacement)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Image != nil {
+		in, out := &in.Image, &out.Image
+		*out = new(ImageSettings)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Certgen != nil {
+		in, out := &in.Certgen, &out.Certgen
+		*out = new(CertgenSettings)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ImagePullSecretRefs != nil {
+		in, out := &in.ImagePullSecretRefs, &out.ImagePullSecretRefs
+		*out = make([]ImagePullSecretRef, len(*in))
+		copy(*out, *in)
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(ContainerResources)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.EnableExternalNameService != nil {
 		in, out := &in.EnableExternalNameService, &out.EnableExternalNameService
 		*out = new(bool)
 		**out = **in
 	}
+	if in.DisablePermitInsecure != nil {
+		in, out := &in.DisablePermitInsecure, &out.DisablePermitInsecure
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Contour != nil {
+		in, out := &in.Contour, &out.Contour
+		*out = new(ContourSettings)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Envoy != nil {
+		in, out := &in.Envoy, &out.Envoy
+		*out = new(EnvoySettings)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TTLSecondsAfterCreation != nil {
+		in, out := &in.TTLSecondsAfterCreation, &out.TTLSecondsAfterCreation
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ProxyConfig != nil {
+		in, out := &in.ProxyConfig, &out.ProxyConfig
+		*out = new(ProxyConfig)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContourSpec.
@@ -242,197 +460,966 @@ func (in *ContourStatus) DeepCopy() *ContourStatus {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *EnvoyNetworkPublishing) DeepCopyInto(out *EnvoyNetworkPublishing) {
+func (in *EnvoyAccessLog) DeepCopyInto(out *EnvoyAccessLog) {
 	*out = *in
-	in.LoadBalancer.DeepCopyInto(&out.LoadBalancer)
-	if in.NodePorts != nil {
-		in, out := &in.NodePorts, &out.NodePorts
-		*out = make([]NodePort, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.ContainerPorts != nil {
-		in, out := &in.ContainerPorts, &out.ContainerPorts
-		*out = make([]ContainerPort, len(*in))
+	if in.JSONFields != nil {
+		in, out := &in.JSONFields, &out.JSONFields
+		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvoyNetworkPublishing.
-func (in *EnvoyNetworkPublishing) DeepCopy() *EnvoyNetworkPublishing {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvoyAccessLog.
+func (in *EnvoyAccessLog) DeepCopy() *EnvoyAccessLog {
 	if in == nil {
 		return nil
 	}
-	out := new(EnvoyNetworkPublishing)
+	out := new(EnvoyAccessLog)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *EnvoyNodePlacement) DeepCopyInto(out *EnvoyNodePlacement) {
+func (in *EnvoyAutoscaling) DeepCopyInto(out *EnvoyAutoscaling) {
 	*out = *in
-	if in.NodeSelector != nil {
-		in, out := &in.NodeSelector, &out.NodeSelector
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+	if in.MinReplicas != nil {
+		in, out := &in.MinReplicas, &out.MinReplicas
+		*out = new(int32)
+		**out = **in
 	}
-	if in.Tolerations != nil {
-		in, out := &in.Tolerations, &out.Tolerations
-		*out = make([]v1.Toleration, len(*in))
+	if in.TargetCPUUtilizationPercentage != nil {
+		in, out := &in.TargetCPUUtilizationPercentage, &out.TargetCPUUtilizationPercentage
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TargetMemoryUtilizationPercentage != nil {
+		in, out := &in.TargetMemoryUtilizationPercentage, &out.TargetMemoryUtilizationPercentage
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Metrics != nil {
+		in, out := &in.Metrics, &out.Metrics
+		*out = make([]v2.MetricSpec, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvoyNodePlacement.
-func (in *EnvoyNodePlacement) DeepCopy() *EnvoyNodePlacement {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvoyAutoscaling.
+func (in *EnvoyAutoscaling) DeepCopy() *EnvoyAutoscaling {
 	if in == nil {
 		return nil
 	}
-	out := new(EnvoyNodePlacement)
+	out := new(EnvoyAutoscaling)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GCPLoadBalancerParameters) DeepCopyInto(out *GCPLoadBalancerParameters) {
+func (in *EnvoyBootstrapSettings) DeepCopyInto(out *EnvoyBootstrapSettings) {
 	*out = *in
-	if in.Address != nil {
-		in, out := &in.Address, &out.Address
-		*out = new(string)
+	if in.XDSPort != nil {
+		in, out := &in.XDSPort, &out.XDSPort
+		*out = new(int32)
 		**out = **in
 	}
-	if in.Subnet != nil {
-		in, out := &in.Subnet, &out.Subnet
-		*out = new(string)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvoyBootstrapSettings.
+func (in *EnvoyBootstrapSettings) DeepCopy() *EnvoyBootstrapSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(EnvoyBootstrapSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnvoyClusterSettings) DeepCopyInto(out *EnvoyClusterSettings) {
+	*out = *in
+	if in.PerConnectionBufferLimitBytes != nil {
+		in, out := &in.PerConnectionBufferLimitBytes, &out.PerConnectionBufferLimitBytes
+		*out = new(int32)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPLoadBalancerParameters.
-func (in *GCPLoadBalancerParameters) DeepCopy() *GCPLoadBalancerParameters {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvoyClusterSettings.
+func (in *EnvoyClusterSettings) DeepCopy() *EnvoyClusterSettings {
 	if in == nil {
 		return nil
 	}
-	out := new(GCPLoadBalancerParameters)
+	out := new(EnvoyClusterSettings)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LoadBalancerStrategy) DeepCopyInto(out *LoadBalancerStrategy) {
+func (in *EnvoyCompression) DeepCopyInto(out *EnvoyCompression) {
 	*out = *in
-	in.ProviderParameters.DeepCopyInto(&out.ProviderParameters)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoadBalancerStrategy.
-func (in *LoadBalancerStrategy) DeepCopy() *LoadBalancerStrategy {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvoyCompression.
+func (in *EnvoyCompression) DeepCopy() *EnvoyCompression {
 	if in == nil {
 		return nil
 	}
-	out := new(LoadBalancerStrategy)
+	out := new(EnvoyCompression)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NamespaceSpec) DeepCopyInto(out *NamespaceSpec) {
+func (in *EnvoyConcurrency) DeepCopyInto(out *EnvoyConcurrency) {
 	*out = *in
+	if in.Workers != nil {
+		in, out := &in.Workers, &out.Workers
+		*out = new(int32)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceSpec.
-func (in *NamespaceSpec) DeepCopy() *NamespaceSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvoyConcurrency.
+func (in *EnvoyConcurrency) DeepCopy() *EnvoyConcurrency {
 	if in == nil {
 		return nil
 	}
-	out := new(NamespaceSpec)
+	out := new(EnvoyConcurrency)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NetworkPublishing) DeepCopyInto(out *NetworkPublishing) {
+func (in *EnvoyListenerSettings) DeepCopyInto(out *EnvoyListenerSettings) {
 	*out = *in
-	in.Envoy.DeepCopyInto(&out.Envoy)
+	if in.PerConnectionBufferLimitBytes != nil {
+		in, out := &in.PerConnectionBufferLimitBytes, &out.PerConnectionBufferLimitBytes
+		*out = new(int32)
+		**out = **in
+	}
+	if in.HTTP2MaxConcurrentStreams != nil {
+		in, out := &in.HTTP2MaxConcurrentStreams, &out.HTTP2MaxConcurrentStreams
+		*out = new(int32)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkPublishing.
-func (in *NetworkPublishing) DeepCopy() *NetworkPublishing {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvoyListenerSettings.
+func (in *EnvoyListenerSettings) DeepCopy() *EnvoyListenerSettings {
 	if in == nil {
 		return nil
 	}
-	out := new(NetworkPublishing)
+	out := new(EnvoyListenerSettings)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NodePlacement) DeepCopyInto(out *NodePlacement) {
+func (in *EnvoyNetworkPublishing) DeepCopyInto(out *EnvoyNetworkPublishing) {
 	*out = *in
-	if in.Contour != nil {
-		in, out := &in.Contour, &out.Contour
-		*out = new(ContourNodePlacement)
-		(*in).DeepCopyInto(*out)
+	in.LoadBalancer.DeepCopyInto(&out.LoadBalancer)
+	if in.NodePorts != nil {
+		in, out := &in.NodePorts, &out.NodePorts
+		*out = make([]NodePort, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
-	if in.Envoy != nil {
-		in, out := &in.Envoy, &out.Envoy
-		*out = new(EnvoyNodePlacement)
+	if in.ContainerPorts != nil {
+		in, out := &in.ContainerPorts, &out.ContainerPorts
+		*out = make([]ContainerPort, len(*in))
+		copy(*out, *in)
+	}
+	if in.ServicePorts != nil {
+		in, out := &in.ServicePorts, &out.ServicePorts
+		*out = make([]ServicePort, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExtraPorts != nil {
+		in, out := &in.ExtraPorts, &out.ExtraPorts
+		*out = make([]ExtraPort, len(*in))
+		copy(*out, *in)
+	}
+	if in.Hostname != nil {
+		in, out := &in.Hostname, &out.Hostname
+		*out = new(string)
+		**out = **in
+	}
+	if in.SessionAffinityConfig != nil {
+		in, out := &in.SessionAffinityConfig, &out.SessionAffinityConfig
+		*out = new(v1.SessionAffinityConfig)
 		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodePlacement.
-func (in *NodePlacement) DeepCopy() *NodePlacement {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvoyNetworkPublishing.
+func (in *EnvoyNetworkPublishing) DeepCopy() *EnvoyNetworkPublishing {
 	if in == nil {
 		return nil
 	}
-	out := new(NodePlacement)
+	out := new(EnvoyNetworkPublishing)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NodePort) DeepCopyInto(out *NodePort) {
+func (in *EnvoyNetworkSettings) DeepCopyInto(out *EnvoyNetworkSettings) {
 	*out = *in
-	if in.PortNumber != nil {
-		in, out := &in.PortNumber, &out.PortNumber
+	if in.NumTrustedHops != nil {
+		in, out := &in.NumTrustedHops, &out.NumTrustedHops
 		*out = new(int32)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodePort.
-func (in *NodePort) DeepCopy() *NodePort {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvoyNetworkSettings.
+func (in *EnvoyNetworkSettings) DeepCopy() *EnvoyNetworkSettings {
 	if in == nil {
 		return nil
 	}
-	out := new(NodePort)
+	out := new(EnvoyNetworkSettings)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ProviderLoadBalancerParameters) DeepCopyInto(out *ProviderLoadBalancerParameters) {
+func (in *EnvoyNodePlacement) DeepCopyInto(out *EnvoyNodePlacement) {
 	*out = *in
-	if in.AWS != nil {
-		in, out := &in.AWS, &out.AWS
-		*out = new(AWSLoadBalancerParameters)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Azure != nil {
-		in, out := &in.Azure, &out.Azure
-		*out = new(AzureLoadBalancerParameters)
-		(*in).DeepCopyInto(*out)
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]v1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(v1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TopologySpreadConstraints != nil {
+		in, out := &in.TopologySpreadConstraints, &out.TopologySpreadConstraints
+		*out = make([]v1.TopologySpreadConstraint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvoyNodePlacement.
+func (in *EnvoyNodePlacement) DeepCopy() *EnvoyNodePlacement {
+	if in == nil {
+		return nil
+	}
+	out := new(EnvoyNodePlacement)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnvoySettings) DeepCopyInto(out *EnvoySettings) {
+	*out = *in
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.UpdateStrategy != nil {
+		in, out := &in.UpdateStrategy, &out.UpdateStrategy
+		*out = new(appsv1.DaemonSetUpdateStrategy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = new(EnvoyAutoscaling)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(EnvoyTLS)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HeadersPolicy != nil {
+		in, out := &in.HeadersPolicy, &out.HeadersPolicy
+		*out = new(GlobalHeadersPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RateLimitService != nil {
+		in, out := &in.RateLimitService, &out.RateLimitService
+		*out = new(RateLimitServiceSettings)
+		**out = **in
+	}
+	if in.AccessLog != nil {
+		in, out := &in.AccessLog, &out.AccessLog
+		*out = new(EnvoyAccessLog)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Compression != nil {
+		in, out := &in.Compression, &out.Compression
+		*out = new(EnvoyCompression)
+		**out = **in
+	}
+	if in.Listener != nil {
+		in, out := &in.Listener, &out.Listener
+		*out = new(EnvoyListenerSettings)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Cluster != nil {
+		in, out := &in.Cluster, &out.Cluster
+		*out = new(EnvoyClusterSettings)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Network != nil {
+		in, out := &in.Network, &out.Network
+		*out = new(EnvoyNetworkSettings)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DefaultHTTPVersions != nil {
+		in, out := &in.DefaultHTTPVersions, &out.DefaultHTTPVersions
+		*out = make([]HTTPVersionType, len(*in))
+		copy(*out, *in)
+	}
+	if in.Timeouts != nil {
+		in, out := &in.Timeouts, &out.Timeouts
+		*out = new(EnvoyTimeouts)
+		**out = **in
+	}
+	if in.PodAnnotations != nil {
+		in, out := &in.PodAnnotations, &out.PodAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.PodLabels != nil {
+		in, out := &in.PodLabels, &out.PodLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.PodSecurityContext != nil {
+		in, out := &in.PodSecurityContext, &out.PodSecurityContext
+		*out = new(v1.PodSecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecurityContext != nil {
+		in, out := &in.SecurityContext, &out.SecurityContext
+		*out = new(v1.SecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DNSConfig != nil {
+		in, out := &in.DNSConfig, &out.DNSConfig
+		*out = new(v1.PodDNSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TerminationGracePeriodSeconds != nil {
+		in, out := &in.TerminationGracePeriodSeconds, &out.TerminationGracePeriodSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Shutdown != nil {
+		in, out := &in.Shutdown, &out.Shutdown
+		*out = new(EnvoyShutdownSettings)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Bootstrap != nil {
+		in, out := &in.Bootstrap, &out.Bootstrap
+		*out = new(EnvoyBootstrapSettings)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ReadinessProbe != nil {
+		in, out := &in.ReadinessProbe, &out.ReadinessProbe
+		*out = new(ProbeSettings)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Concurrency != nil {
+		in, out := &in.Concurrency, &out.Concurrency
+		*out = new(EnvoyConcurrency)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExtraVolumes != nil {
+		in, out := &in.ExtraVolumes, &out.ExtraVolumes
+		*out = make([]v1.Volume, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ExtraVolumeMounts != nil {
+		in, out := &in.ExtraVolumeMounts, &out.ExtraVolumeMounts
+		*out = make([]v1.VolumeMount, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ExtraEnv != nil {
+		in, out := &in.ExtraEnv, &out.ExtraEnv
+		*out = make([]v1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ExtraContainers != nil {
+		in, out := &in.ExtraContainers, &out.ExtraContainers
+		*out = make([]v1.Container, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ExtraInitContainers != nil {
+		in, out := &in.ExtraInitContainers, &out.ExtraInitContainers
+		*out = make([]v1.Container, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PodDisruptionBudget != nil {
+		in, out := &in.PodDisruptionBudget, &out.PodDisruptionBudget
+		*out = new(PodDisruptionBudgetSettings)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvoySettings.
+func (in *EnvoySettings) DeepCopy() *EnvoySettings {
+	if in == nil {
+		return nil
+	}
+	out := new(EnvoySettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnvoyShutdownSettings) DeepCopyInto(out *EnvoyShutdownSettings) {
+	*out = *in
+	if in.DrainDelay != nil {
+		in, out := &in.DrainDelay, &out.DrainDelay
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.CheckDelay != nil {
+		in, out := &in.CheckDelay, &out.CheckDelay
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.CheckInterval != nil {
+		in, out := &in.CheckInterval, &out.CheckInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.MinOpenConnections != nil {
+		in, out := &in.MinOpenConnections, &out.MinOpenConnections
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvoyShutdownSettings.
+func (in *EnvoyShutdownSettings) DeepCopy() *EnvoyShutdownSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(EnvoyShutdownSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnvoyTLS) DeepCopyInto(out *EnvoyTLS) {
+	*out = *in
+	if in.CipherSuites != nil {
+		in, out := &in.CipherSuites, &out.CipherSuites
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ClientCertificate != nil {
+		in, out := &in.ClientCertificate, &out.ClientCertificate
+		*out = new(SecretReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvoyTLS.
+func (in *EnvoyTLS) DeepCopy() *EnvoyTLS {
+	if in == nil {
+		return nil
+	}
+	out := new(EnvoyTLS)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnvoyTimeouts) DeepCopyInto(out *EnvoyTimeouts) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvoyTimeouts.
+func (in *EnvoyTimeouts) DeepCopy() *EnvoyTimeouts {
+	if in == nil {
+		return nil
+	}
+	out := new(EnvoyTimeouts)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExtensionServiceReference) DeepCopyInto(out *ExtensionServiceReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExtensionServiceReference.
+func (in *ExtensionServiceReference) DeepCopy() *ExtensionServiceReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ExtensionServiceReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExtraPort) DeepCopyInto(out *ExtraPort) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExtraPort.
+func (in *ExtraPort) DeepCopy() *ExtraPort {
+	if in == nil {
+		return nil
+	}
+	out := new(ExtraPort)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPLoadBalancerParameters) DeepCopyInto(out *GCPLoadBalancerParameters) {
+	*out = *in
+	if in.Address != nil {
+		in, out := &in.Address, &out.Address
+		*out = new(string)
+		**out = **in
+	}
+	if in.Subnet != nil {
+		in, out := &in.Subnet, &out.Subnet
+		*out = new(string)
+		**out = **in
+	}
+	if in.NetworkEndpointGroups != nil {
+		in, out := &in.NetworkEndpointGroups, &out.NetworkEndpointGroups
+		*out = new(GCPNetworkEndpointGroups)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPLoadBalancerParameters.
+func (in *GCPLoadBalancerParameters) DeepCopy() *GCPLoadBalancerParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPLoadBalancerParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPNetworkEndpointGroups) DeepCopyInto(out *GCPNetworkEndpointGroups) {
+	*out = *in
+	if in.ExposedPorts != nil {
+		in, out := &in.ExposedPorts, &out.ExposedPorts
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPNetworkEndpointGroups.
+func (in *GCPNetworkEndpointGroups) DeepCopy() *GCPNetworkEndpointGroups {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPNetworkEndpointGroups)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GlobalHeadersPolicy) DeepCopyInto(out *GlobalHeadersPolicy) {
+	*out = *in
+	if in.Request != nil {
+		in, out := &in.Request, &out.Request
+		*out = new(HeadersPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Response != nil {
+		in, out := &in.Response, &out.Response
+		*out = new(HeadersPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GlobalHeadersPolicy.
+func (in *GlobalHeadersPolicy) DeepCopy() *GlobalHeadersPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(GlobalHeadersPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HeadersPolicy) DeepCopyInto(out *HeadersPolicy) {
+	*out = *in
+	if in.Set != nil {
+		in, out := &in.Set, &out.Set
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Remove != nil {
+		in, out := &in.Remove, &out.Remove
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HeadersPolicy.
+func (in *HeadersPolicy) DeepCopy() *HeadersPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(HeadersPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageOverride) DeepCopyInto(out *ImageOverride) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageOverride.
+func (in *ImageOverride) DeepCopy() *ImageOverride {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageOverride)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImagePullSecretRef) DeepCopyInto(out *ImagePullSecretRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImagePullSecretRef.
+func (in *ImagePullSecretRef) DeepCopy() *ImagePullSecretRef {
+	if in == nil {
+		return nil
+	}
+	out := new(ImagePullSecretRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageSettings) DeepCopyInto(out *ImageSettings) {
+	*out = *in
+	if in.Contour != nil {
+		in, out := &in.Contour, &out.Contour
+		*out = new(ImageOverride)
+		**out = **in
+	}
+	if in.Envoy != nil {
+		in, out := &in.Envoy, &out.Envoy
+		*out = new(ImageOverride)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageSettings.
+func (in *ImageSettings) DeepCopy() *ImageSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LoadBalancerStrategy) DeepCopyInto(out *LoadBalancerStrategy) {
+	*out = *in
+	in.ProviderParameters.DeepCopyInto(&out.ProviderParameters)
+	if in.ProxyProtocol != nil {
+		in, out := &in.ProxyProtocol, &out.ProxyProtocol
+		*out = new(bool)
+		**out = **in
+	}
+	if in.LoadBalancerClass != nil {
+		in, out := &in.LoadBalancerClass, &out.LoadBalancerClass
+		*out = new(string)
+		**out = **in
+	}
+	if in.AllocateLoadBalancerNodePorts != nil {
+		in, out := &in.AllocateLoadBalancerNodePorts, &out.AllocateLoadBalancerNodePorts
+		*out = new(bool)
+		**out = **in
+	}
+	if in.HealthCheckNodePort != nil {
+		in, out := &in.HealthCheckNodePort, &out.HealthCheckNodePort
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoadBalancerStrategy.
+func (in *LoadBalancerStrategy) DeepCopy() *LoadBalancerStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(LoadBalancerStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetalLBLoadBalancerParameters) DeepCopyInto(out *MetalLBLoadBalancerParameters) {
+	*out = *in
+	if in.AddressPool != nil {
+		in, out := &in.AddressPool, &out.AddressPool
+		*out = new(string)
+		**out = **in
+	}
+	if in.AllowSharedIP != nil {
+		in, out := &in.AllowSharedIP, &out.AllowSharedIP
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetalLBLoadBalancerParameters.
+func (in *MetalLBLoadBalancerParameters) DeepCopy() *MetalLBLoadBalancerParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(MetalLBLoadBalancerParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetricsTLS) DeepCopyInto(out *MetricsTLS) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricsTLS.
+func (in *MetricsTLS) DeepCopy() *MetricsTLS {
+	if in == nil {
+		return nil
+	}
+	out := new(MetricsTLS)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespaceSpec) DeepCopyInto(out *NamespaceSpec) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceSpec.
+func (in *NamespaceSpec) DeepCopy() *NamespaceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespaceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkPublishing) DeepCopyInto(out *NetworkPublishing) {
+	*out = *in
+	in.Envoy.DeepCopyInto(&out.Envoy)
+	if in.IPFamilyPolicy != nil {
+		in, out := &in.IPFamilyPolicy, &out.IPFamilyPolicy
+		*out = new(v1.IPFamilyPolicyType)
+		**out = **in
+	}
+	if in.IPFamilies != nil {
+		in, out := &in.IPFamilies, &out.IPFamilies
+		*out = make([]v1.IPFamily, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkPublishing.
+func (in *NetworkPublishing) DeepCopy() *NetworkPublishing {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkPublishing)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodePlacement) DeepCopyInto(out *NodePlacement) {
+	*out = *in
+	if in.Contour != nil {
+		in, out := &in.Contour, &out.Contour
+		*out = new(ContourNodePlacement)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Envoy != nil {
+		in, out := &in.Envoy, &out.Envoy
+		*out = new(EnvoyNodePlacement)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodePlacement.
+func (in *NodePlacement) DeepCopy() *NodePlacement {
+	if in == nil {
+		return nil
+	}
+	out := new(NodePlacement)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodePort) DeepCopyInto(out *NodePort) {
+	*out = *in
+	if in.PortNumber != nil {
+		in, out := &in.PortNumber, &out.PortNumber
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodePort.
+func (in *NodePort) DeepCopy() *NodePort {
+	if in == nil {
+		return nil
+	}
+	out := new(NodePort)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodDisruptionBudgetSettings) DeepCopyInto(out *PodDisruptionBudgetSettings) {
+	*out = *in
+	if in.MinAvailable != nil {
+		in, out := &in.MinAvailable, &out.MinAvailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodDisruptionBudgetSettings.
+func (in *PodDisruptionBudgetSettings) DeepCopy() *PodDisruptionBudgetSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(PodDisruptionBudgetSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProbeSettings) DeepCopyInto(out *ProbeSettings) {
+	*out = *in
+	if in.InitialDelaySeconds != nil {
+		in, out := &in.InitialDelaySeconds, &out.InitialDelaySeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.PeriodSeconds != nil {
+		in, out := &in.PeriodSeconds, &out.PeriodSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.FailureThreshold != nil {
+		in, out := &in.FailureThreshold, &out.FailureThreshold
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProbeSettings.
+func (in *ProbeSettings) DeepCopy() *ProbeSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(ProbeSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProviderLoadBalancerParameters) DeepCopyInto(out *ProviderLoadBalancerParameters) {
+	*out = *in
+	if in.AWS != nil {
+		in, out := &in.AWS, &out.AWS
+		*out = new(AWSLoadBalancerParameters)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Azure != nil {
+		in, out := &in.Azure, &out.Azure
+		*out = new(AzureLoadBalancerParameters)
+		(*in).DeepCopyInto(*out)
 	}
 	if in.GCP != nil {
 		in, out := &in.GCP, &out.GCP
 		*out = new(GCPLoadBalancerParameters)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.MetalLB != nil {
+		in, out := &in.MetalLB, &out.MetalLB
+		*out = new(MetalLBLoadBalancerParameters)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderLoadBalancerParameters.
@@ -444,3 +1431,64 @@ func (in *ProviderLoadBalancerParameters) DeepCopy() *ProviderLoadBalancerParame
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProxyConfig) DeepCopyInto(out *ProxyConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyConfig.
+func (in *ProxyConfig) DeepCopy() *ProxyConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ProxyConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RateLimitServiceSettings) DeepCopyInto(out *RateLimitServiceSettings) {
+	*out = *in
+	out.ExtensionService = in.ExtensionService
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RateLimitServiceSettings.
+func (in *RateLimitServiceSettings) DeepCopy() *RateLimitServiceSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(RateLimitServiceSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretReference) DeepCopyInto(out *SecretReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretReference.
+func (in *SecretReference) DeepCopy() *SecretReference {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServicePort) DeepCopyInto(out *ServicePort) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServicePort.
+func (in *ServicePort) DeepCopy() *ServicePort {
+	if in == nil {
+		return nil
+	}
+	out := new(ServicePort)
+	in.DeepCopyInto(out)
+	return out
+}
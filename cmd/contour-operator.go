@@ -16,6 +16,7 @@ package main
 import (
 	"flag"
 	"os"
+	"strings"
 
 	"github.com/projectcontour/contour-operator/internal/operator"
 	"github.com/projectcontour/contour-operator/internal/parse"
@@ -36,9 +37,23 @@ func main() {
 		"address the metric endpoint binds to. It can be set to \"0\" to disable serving metrics.")
 	flag.BoolVar(&config.LeaderElection, "enable-leader-election", config.LeaderElection,
 		"Enable leader election for the operator. Enabling this will ensure there is only one active operator.")
+	var imagePullSecrets string
+	flag.StringVar(&imagePullSecrets, "image-pull-secrets", "", "Comma-separated names of the image pull "+
+		"secrets used by Contours that don't specify imagePullSecretRefs.")
+	flag.BoolVar(&config.MirrorImagePullSecrets, "mirror-image-pull-secrets", config.MirrorImagePullSecrets,
+		"Mirror the default image pull secrets from the operator namespace into the managed namespace of each Contour.")
+	flag.StringVar(&config.OperatorNamespace, "operator-namespace", os.Getenv("POD_NAMESPACE"),
+		"The namespace the operator runs in, from which image pull secrets are mirrored. "+
+			"Defaults to the POD_NAMESPACE environment variable.")
 
 	flag.Parse()
 
+	for _, name := range strings.Split(imagePullSecrets, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			config.ImagePullSecrets = append(config.ImagePullSecrets, name)
+		}
+	}
+
 	ctrl.SetLogger(zap.New(zap.UseDevMode(true)))
 	setupLog := ctrl.Log.WithName("setup")
 
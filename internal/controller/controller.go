@@ -16,6 +16,7 @@ package controller
 import (
 	"context"
 	"fmt"
+	"time"
 
 	operatorv1alpha1 "github.com/projectcontour/contour-operator/api/v1alpha1"
 	objutil "github.com/projectcontour/contour-operator/internal/objects"
@@ -23,8 +24,12 @@ import (
 	objcontour "github.com/projectcontour/contour-operator/internal/objects/contour"
 	objds "github.com/projectcontour/contour-operator/internal/objects/daemonset"
 	objdeploy "github.com/projectcontour/contour-operator/internal/objects/deployment"
+	objhpa "github.com/projectcontour/contour-operator/internal/objects/hpa"
+	objic "github.com/projectcontour/contour-operator/internal/objects/ingressclass"
 	objjob "github.com/projectcontour/contour-operator/internal/objects/job"
 	objns "github.com/projectcontour/contour-operator/internal/objects/namespace"
+	objpdb "github.com/projectcontour/contour-operator/internal/objects/pdb"
+	objsecret "github.com/projectcontour/contour-operator/internal/objects/secret"
 	objsvc "github.com/projectcontour/contour-operator/internal/objects/service"
 	retryable "github.com/projectcontour/contour-operator/internal/retryableerror"
 	"github.com/projectcontour/contour-operator/internal/status"
@@ -32,10 +37,12 @@ import (
 
 	"github.com/go-logr/logr"
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
@@ -54,13 +61,25 @@ type Config struct {
 	ContourImage string
 	// EnvoyImage is the name of the Envoy container image.
 	EnvoyImage string
+	// ImagePullSecrets are the names of the image pull secrets used by
+	// contours that don't specify imagePullSecretRefs.
+	ImagePullSecrets []string
+	// MirrorImagePullSecrets determines whether ImagePullSecrets are mirrored
+	// into the namespace of the managed resources.
+	MirrorImagePullSecrets bool
+	// OperatorNamespace is the namespace mirrored image pull secrets are
+	// copied from. If empty, the namespace of each contour is used.
+	OperatorNamespace string
 }
 
 // reconciler reconciles a Contour object.
 type reconciler struct {
 	config Config
 	client client.Client
-	log    logr.Logger
+	// cache reads the objects watched by the controller, e.g. contours,
+	// without a request to the API server.
+	cache client.Reader
+	log   logr.Logger
 }
 
 // New creates the contour controller from mgr and cfg. The controller will be pre-configured
@@ -69,6 +88,7 @@ func New(mgr manager.Manager, cfg Config) (controller.Controller, error) {
 	r := &reconciler{
 		config: cfg,
 		client: mgr.GetClient(),
+		cache:  mgr.GetCache(),
 		log:    ctrl.Log.WithName(controllerName),
 	}
 	c, err := controller.New(controllerName, mgr, controller.Options{Reconciler: r})
@@ -85,28 +105,91 @@ func New(mgr manager.Manager, cfg Config) (controller.Controller, error) {
 	if err := c.Watch(&source.Kind{Type: &appsv1.DaemonSet{}}, r.enqueueRequestForOwningContour()); err != nil {
 		return nil, err
 	}
+	// Watch Services to report the hostname once the Envoy Service's load
+	// balancer has been provisioned.
+	if err := c.Watch(&source.Kind{Type: &corev1.Service{}}, r.enqueueRequestForOwningContour()); err != nil {
+		return nil, err
+	}
+	// Watch mirrored Secrets to revert changes to the copies. The manager's
+	// cache only holds Secrets labeled as mirrored.
+	if err := c.Watch(&source.Kind{Type: &corev1.Secret{}}, r.enqueueRequestForOwningContour()); err != nil {
+		return nil, err
+	}
+	// Watch Secrets in the operator namespace to sync mirrored Secrets with
+	// their source. Without an operator namespace, sources are only read
+	// when a contour is reconciled.
+	if cfg.OperatorNamespace != "" {
+		nsCache, err := cache.New(mgr.GetConfig(), cache.Options{
+			Scheme:    mgr.GetScheme(),
+			Mapper:    mgr.GetRESTMapper(),
+			Namespace: cfg.OperatorNamespace,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if err := mgr.Add(nsCache); err != nil {
+			return nil, err
+		}
+		if err := c.Watch(source.NewKindWithCache(&corev1.Secret{}, nsCache), r.enqueueRequestForMirroringContour()); err != nil {
+			return nil, err
+		}
+	}
 	return c, nil
 }
 
 // enqueueRequestForOwningContour returns an event handler that maps events to
 // objects containing Contour owner labels.
 func (r *reconciler) enqueueRequestForOwningContour() handler.EventHandler {
-	return handler.EnqueueRequestsFromMapFunc(func(a client.Object) []reconcile.Request {
-		labels := a.GetLabels()
-		ns, nsFound := labels[operatorv1alpha1.OwningContourNsLabel]
-		name, nameFound := labels[operatorv1alpha1.OwningContourNameLabel]
-		if nsFound && nameFound {
-			r.log.Info("queueing contour", "namespace", ns, "name", name, "related", a.GetSelfLink())
-			return []reconcile.Request{
-				{
-					NamespacedName: types.NamespacedName{
-						Namespace: ns,
-						Name:      name,
-					},
+	return handler.EnqueueRequestsFromMapFunc(r.owningContourRequests)
+}
+
+// owningContourRequests maps a to a request for the contour referenced by
+// its owner labels, if any.
+func (r *reconciler) owningContourRequests(a client.Object) []reconcile.Request {
+	labels := a.GetLabels()
+	ns, nsFound := labels[operatorv1alpha1.OwningContourNsLabel]
+	name, nameFound := labels[operatorv1alpha1.OwningContourNameLabel]
+	if nsFound && nameFound {
+		r.log.Info("queueing contour", "namespace", ns, "name", name, "related", a.GetSelfLink())
+		return []reconcile.Request{
+			{
+				NamespacedName: types.NamespacedName{
+					Namespace: ns,
+					Name:      name,
 				},
+			},
+		}
+	}
+	return []reconcile.Request{}
+}
+
+// enqueueRequestForMirroringContour returns an event handler that maps events
+// for secrets in the operator namespace to the contours mirroring them. Contours
+// are listed from the cache to avoid a request to the API server per event.
+func (r *reconciler) enqueueRequestForMirroringContour() handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(a client.Object) []reconcile.Request {
+		contours := &operatorv1alpha1.ContourList{}
+		if err := r.cache.List(context.Background(), contours); err != nil {
+			r.log.Error(err, "failed to list contours", "related", a.GetSelfLink())
+			return []reconcile.Request{}
+		}
+		var requests []reconcile.Request
+		for i := range contours.Items {
+			contour := r.withDefaults(&contours.Items[i])
+			for _, ref := range contour.Spec.ImagePullSecretRefs {
+				if ref.Mirror && ref.Name == a.GetName() {
+					r.log.Info("queueing contour", "namespace", contour.Namespace, "name", contour.Name, "related", a.GetSelfLink())
+					requests = append(requests, reconcile.Request{
+						NamespacedName: types.NamespacedName{
+							Namespace: contour.Namespace,
+							Name:      contour.Name,
+						},
+					})
+					break
+				}
 			}
 		}
-		return []reconcile.Request{}
+		return requests
 	})
 }
 
@@ -130,8 +213,24 @@ func (r *reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 	}
 	// The contour is safe to process, so ensure current state matches desired state.
 	desired := contour.ObjectMeta.DeletionTimestamp.IsZero()
+	var requeueAfter time.Duration
+	if expiration, ok := contour.ExpirationTime(); ok && desired {
+		requeueAfter = time.Until(expiration)
+		if requeueAfter <= 0 {
+			// Deleting the contour cleans up its resources through the finalizer.
+			if err := r.client.Delete(ctx, contour); err != nil && !errors.IsNotFound(err) {
+				return ctrl.Result{}, fmt.Errorf("failed to delete expired contour %s/%s: %w", contour.Namespace, contour.Name, err)
+			}
+			r.log.Info("deleted expired contour", "namespace", contour.Namespace, "name", contour.Name)
+			return ctrl.Result{}, nil
+		}
+	}
 	if desired {
 		if err := validation.Contour(ctx, r.client, contour); err != nil {
+			// Surface the validation error on the contour's status.
+			if err := status.SyncContour(ctx, r.client, contour, err); err != nil {
+				r.log.Error(err, "failed to sync status for invalid contour", "namespace", contour.Namespace, "name", contour.Name)
+			}
 			return ctrl.Result{}, fmt.Errorf("failed to validate contour %s/%s: %w", contour.Namespace, contour.Name, err)
 		}
 		if !contour.IsFinalized() {
@@ -166,13 +265,15 @@ func (r *reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 		}
 		r.log.Info("deleted contour", "namespace", contour.Namespace, "name", contour.Name)
 	}
-	return ctrl.Result{}, nil
+	// Requeue expiring contours so they are deleted on time.
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
 }
 
 // ensureContour ensures all necessary resources exist for the given contour.
 func (r *reconciler) ensureContour(ctx context.Context, contour *operatorv1alpha1.Contour) error {
 	var errs []error
 	cli := r.client
+	contour = r.withDefaults(contour)
 
 	handleResult := func(resource string, err error) {
 		if err != nil {
@@ -183,7 +284,7 @@ func (r *reconciler) ensureContour(ctx context.Context, contour *operatorv1alpha
 	}
 
 	syncContourStatus := func() error {
-		if err := status.SyncContour(ctx, cli, contour); err != nil {
+		if err := status.SyncContour(ctx, cli, contour, nil); err != nil {
 			errs = append(errs, fmt.Errorf("failed to sync status for contour %s/%s: %w", contour.Namespace, contour.Name, err))
 		} else {
 			r.log.Info("synced status for contour", "namespace", contour.Namespace, "name", contour.Name)
@@ -198,23 +299,113 @@ func (r *reconciler) ensureContour(ctx context.Context, contour *operatorv1alpha
 		return syncContourStatus()
 	}
 
-	contourImage := r.config.ContourImage
-	envoyImage := r.config.EnvoyImage
+	// paused returns true if reconciliation of kind is paused for contour,
+	// e.g. to freeze the data plane during incident response.
+	paused := func(kind string) bool {
+		if contour.KindPaused(kind) {
+			r.log.Info(fmt.Sprintf("reconciliation of %s paused for contour", kind), "namespace", contour.Namespace, "name", contour.Name)
+			return true
+		}
+		return false
+	}
 
-	handleResult("configmap", objcm.EnsureConfigMap(ctx, cli, contour))
-	handleResult("job", objjob.EnsureJob(ctx, cli, contour, contourImage))
-	handleResult("deployment", objdeploy.EnsureDeployment(ctx, cli, contour, contourImage))
-	handleResult("daemonset", objds.EnsureDaemonSet(ctx, cli, contour, contourImage, envoyImage))
-	handleResult("contour service", objsvc.EnsureContourService(ctx, cli, contour))
+	contourImage := objutil.ImageFor(r.config.ContourImage, contour.ContourImageOverride())
+	envoyImage := objutil.ImageFor(r.config.EnvoyImage, contour.EnvoyImageOverride())
 
-	switch contour.Spec.NetworkPublishing.Envoy.Type {
-	case operatorv1alpha1.LoadBalancerServicePublishingType, operatorv1alpha1.NodePortServicePublishingType, operatorv1alpha1.ClusterIPServicePublishingType:
-		handleResult("envoy service", objsvc.EnsureEnvoyService(ctx, cli, contour))
+	if !paused("Secret") {
+		handleResult("image pull secrets", objsecret.EnsureImagePullSecrets(ctx, cli, contour, r.mirrorNamespace(contour)))
+	}
+	if !paused("ConfigMap") {
+		handleResult("configmap", objcm.EnsureConfigMap(ctx, cli, contour))
+	}
+	handleResult("ingress class", objic.EnsureIngressClass(ctx, cli, contour))
+	if !paused("Job") {
+		handleResult("job", objjob.EnsureJob(ctx, cli, contour, contourImage))
+	}
+	if !paused("Deployment") {
+		handleResult("deployment", objdeploy.EnsureDeployment(ctx, cli, contour, contourImage))
+	}
+	// Envoy runs as either a DaemonSet or a Deployment, so remove the
+	// workload that is not in use, e.g. after switching workload types.
+	switch contour.EnvoyWorkloadType() {
+	case operatorv1alpha1.DeploymentEnvoyWorkload:
+		if !paused("DaemonSet") {
+			handleResult("daemonset", objds.EnsureDaemonSetDeleted(ctx, cli, contour))
+		}
+		if !paused("Deployment") {
+			handleResult("envoy deployment", objds.EnsureEnvoyDeployment(ctx, cli, contour, contourImage, envoyImage))
+		}
+	default:
+		if !paused("Deployment") {
+			handleResult("envoy deployment", objds.EnsureEnvoyDeploymentDeleted(ctx, cli, contour))
+		}
+		if !paused("DaemonSet") {
+			handleResult("daemonset", objds.EnsureDaemonSet(ctx, cli, contour, contourImage, envoyImage))
+		}
+	}
+	if !paused("HorizontalPodAutoscaler") {
+		if contour.EnvoyAutoscalingEnabled() {
+			handleResult("envoy horizontalpodautoscaler", objhpa.EnsureEnvoyHPA(ctx, cli, contour))
+		} else {
+			handleResult("envoy horizontalpodautoscaler", objhpa.EnsureEnvoyHPADeleted(ctx, cli, contour))
+		}
+	}
+	if !paused("PodDisruptionBudget") {
+		if contour.ContourPDBEnabled() {
+			handleResult("contour poddisruptionbudget", objpdb.EnsureContourPDB(ctx, cli, contour))
+		} else {
+			handleResult("contour poddisruptionbudget", objpdb.EnsureContourPDBDeleted(ctx, cli, contour))
+		}
+		if contour.EnvoyPDBEnabled() {
+			handleResult("envoy poddisruptionbudget", objpdb.EnsureEnvoyPDB(ctx, cli, contour))
+		} else {
+			handleResult("envoy poddisruptionbudget", objpdb.EnsureEnvoyPDBDeleted(ctx, cli, contour))
+		}
+	}
+	if !paused("Service") {
+		handleResult("contour service", objsvc.EnsureContourService(ctx, cli, contour))
+		handleResult("contour metrics service", objsvc.EnsureContourMetricsService(ctx, cli, contour))
+		handleResult("envoy metrics service", objsvc.EnsureEnvoyMetricsService(ctx, cli, contour))
+
+		// Envoy pods bound to node ports are reached without the Envoy Service.
+		if contour.EnvoyNetworkType() != operatorv1alpha1.ClusterIPEnvoyNetwork {
+			handleResult("envoy service", objsvc.EnsureEnvoyServiceDeleted(ctx, cli, contour))
+		} else {
+			switch contour.Spec.NetworkPublishing.Envoy.Type {
+			case operatorv1alpha1.LoadBalancerServicePublishingType, operatorv1alpha1.NodePortServicePublishingType, operatorv1alpha1.ClusterIPServicePublishingType:
+				handleResult("envoy service", objsvc.EnsureEnvoyService(ctx, cli, contour))
+			}
+		}
 	}
 
 	return syncContourStatus()
 }
 
+// withDefaults returns a copy of contour with the operator's defaults applied
+// to fields the contour leaves unset. The defaults are not persisted.
+func (r *reconciler) withDefaults(contour *operatorv1alpha1.Contour) *operatorv1alpha1.Contour {
+	if len(contour.Spec.ImagePullSecretRefs) > 0 || len(r.config.ImagePullSecrets) == 0 {
+		return contour
+	}
+	defaulted := contour.DeepCopy()
+	for _, name := range r.config.ImagePullSecrets {
+		defaulted.Spec.ImagePullSecretRefs = append(defaulted.Spec.ImagePullSecretRefs, operatorv1alpha1.ImagePullSecretRef{
+			Name:   name,
+			Mirror: r.config.MirrorImagePullSecrets,
+		})
+	}
+	return defaulted
+}
+
+// mirrorNamespace returns the namespace image pull secrets of contour are
+// mirrored from.
+func (r *reconciler) mirrorNamespace(contour *operatorv1alpha1.Contour) string {
+	if r.config.OperatorNamespace != "" {
+		return r.config.OperatorNamespace
+	}
+	return contour.Namespace
+}
+
 // ensureContourDeleted ensures contour and all child resources have been deleted.
 func (r *reconciler) ensureContourDeleted(ctx context.Context, contour *operatorv1alpha1.Contour) error {
 	var errs []error
@@ -233,11 +424,20 @@ func (r *reconciler) ensureContourDeleted(ctx context.Context, contour *operator
 		handleResult("envoy service", objsvc.EnsureEnvoyServiceDeleted(ctx, cli, contour))
 	}
 
+	handleResult("envoy metrics service", objsvc.EnsureEnvoyMetricsServiceDeleted(ctx, cli, contour))
+	handleResult("contour metrics service", objsvc.EnsureContourMetricsServiceDeleted(ctx, cli, contour))
 	handleResult("service", objsvc.EnsureContourServiceDeleted(ctx, cli, contour))
+	handleResult("envoy poddisruptionbudget", objpdb.EnsureEnvoyPDBDeleted(ctx, cli, contour))
+	handleResult("contour poddisruptionbudget", objpdb.EnsureContourPDBDeleted(ctx, cli, contour))
+	handleResult("envoy horizontalpodautoscaler", objhpa.EnsureEnvoyHPADeleted(ctx, cli, contour))
 	handleResult("daemonset", objds.EnsureDaemonSetDeleted(ctx, cli, contour))
+	handleResult("envoy deployment", objds.EnsureEnvoyDeploymentDeleted(ctx, cli, contour))
 	handleResult("deployment", objdeploy.EnsureDeploymentDeleted(ctx, cli, contour))
-	handleResult("job", objjob.EnsureJobDeleted(ctx, cli, contour, r.config.ContourImage))
+	handleResult("job", objjob.EnsureJobDeleted(ctx, cli, contour,
+		objutil.ImageFor(r.config.ContourImage, contour.ContourImageOverride())))
 	handleResult("configmap", objcm.EnsureConfigMapDeleted(ctx, cli, contour))
+	handleResult("ingress class", objic.EnsureIngressClassDeleted(ctx, cli, contour))
+	handleResult("image pull secrets", objsecret.EnsureImagePullSecretsDeleted(ctx, cli, contour))
 	handleResult("rbac", objutil.EnsureRBACDeleted(ctx, cli, contour))
 	if deleteExpected, err := objns.EnsureNamespaceDeleted(ctx, cli, contour); deleteExpected {
 		handleResult("namespace", err)
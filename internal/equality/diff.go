@@ -0,0 +1,129 @@
+// Copyright Project Contour Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package equality
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+)
+
+// Diff returns the JSON field paths at which current and expected differ
+// semantically, e.g. "spec.template.spec.containers[0].image". Structs with
+// unexported fields, such as resource quantities, are compared as a whole.
+func Diff(current, expected interface{}) []string {
+	var paths []string
+	diff(reflect.ValueOf(current), reflect.ValueOf(expected), "", &paths)
+	return paths
+}
+
+func diff(current, expected reflect.Value, path string, paths *[]string) {
+	// A map key that is missing on one side yields an invalid value.
+	if !current.IsValid() || !expected.IsValid() {
+		if current.IsValid() != expected.IsValid() {
+			*paths = append(*paths, path)
+		}
+		return
+	}
+	if current.Type() != expected.Type() {
+		*paths = append(*paths, path)
+		return
+	}
+	if apiequality.Semantic.DeepEqual(current.Interface(), expected.Interface()) {
+		return
+	}
+
+	switch current.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if current.IsNil() || expected.IsNil() {
+			*paths = append(*paths, path)
+			return
+		}
+		diff(current.Elem(), expected.Elem(), path, paths)
+	case reflect.Struct:
+		if !exportedStruct(current.Type()) {
+			*paths = append(*paths, path)
+			return
+		}
+		for i := 0; i < current.NumField(); i++ {
+			name, ok := jsonName(current.Type().Field(i))
+			if !ok {
+				continue
+			}
+			diff(current.Field(i), expected.Field(i), joinPath(path, name), paths)
+		}
+	case reflect.Slice, reflect.Array:
+		if current.Len() != expected.Len() {
+			*paths = append(*paths, path)
+			return
+		}
+		for i := 0; i < current.Len(); i++ {
+			diff(current.Index(i), expected.Index(i), fmt.Sprintf("%s[%d]", path, i), paths)
+		}
+	case reflect.Map:
+		keys := map[string]reflect.Value{}
+		for _, k := range append(current.MapKeys(), expected.MapKeys()...) {
+			keys[fmt.Sprint(k.Interface())] = k
+		}
+		names := make([]string, 0, len(keys))
+		for name := range keys {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			k := keys[name]
+			diff(current.MapIndex(k), expected.MapIndex(k), fmt.Sprintf("%s[%s]", path, name), paths)
+		}
+	default:
+		*paths = append(*paths, path)
+	}
+}
+
+// exportedStruct returns whether all fields of t are exported.
+func exportedStruct(t reflect.Type) bool {
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).PkgPath != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// jsonName returns the JSON name of field, which is empty for inlined
+// fields, and false if the field isn't serialized.
+func jsonName(field reflect.StructField) (string, bool) {
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", false
+	}
+	name := strings.Split(tag, ",")[0]
+	if name == "" && !strings.Contains(tag, "inline") && !field.Anonymous {
+		name = field.Name
+	}
+	return name, true
+}
+
+func joinPath(path, name string) string {
+	switch {
+	case name == "":
+		return path
+	case path == "":
+		return name
+	default:
+		return path + "." + name
+	}
+}
@@ -14,11 +14,16 @@
 package equality
 
 import (
+	"strings"
+
 	operatorv1alpha1 "github.com/projectcontour/contour-operator/api/v1alpha1"
 
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
 )
@@ -35,7 +40,10 @@ func DaemonsetConfigChanged(current, expected *appsv1.DaemonSet) (*appsv1.Daemon
 
 	}
 
-	if !apiequality.Semantic.DeepEqual(current.Spec, expected.Spec) {
+	expectedSpec := *expected.Spec.DeepCopy()
+	expectedSpec.Template = normalizedTemplate(&current.Spec.Template, &expected.Spec.Template)
+
+	if !apiequality.Semantic.DeepEqual(current.Spec, expectedSpec) {
 		changed = true
 		updated.Spec = expected.Spec
 	}
@@ -107,7 +115,10 @@ func DeploymentConfigChanged(current, expected *appsv1.Deployment) (*appsv1.Depl
 		changed = true
 	}
 
-	if !apiequality.Semantic.DeepEqual(current.Spec, expected.Spec) {
+	expectedSpec := *expected.Spec.DeepCopy()
+	expectedSpec.Template = normalizedTemplate(&current.Spec.Template, &expected.Spec.Template)
+
+	if !apiequality.Semantic.DeepEqual(current.Spec, expectedSpec) {
 		updated = expected
 		changed = true
 	}
@@ -119,11 +130,148 @@ func DeploymentConfigChanged(current, expected *appsv1.Deployment) (*appsv1.Depl
 	return updated, true
 }
 
+// normalizedTemplate returns a copy of expected without the differences from
+// current that don't need an update: tolerations listed in another order, and
+// probe fields left unset that the API server defaults.
+func normalizedTemplate(current, expected *corev1.PodTemplateSpec) corev1.PodTemplateSpec {
+	normalized := *expected.DeepCopy()
+	if tolerationsEqual(current.Spec.Tolerations, expected.Spec.Tolerations) {
+		normalized.Spec.Tolerations = current.Spec.Tolerations
+	}
+	for _, containers := range [][]corev1.Container{normalized.Spec.InitContainers, normalized.Spec.Containers} {
+		for i := range containers {
+			c := &containers[i]
+			for _, probe := range []*corev1.Probe{c.LivenessProbe, c.ReadinessProbe, c.StartupProbe} {
+				DefaultProbe(probe)
+			}
+		}
+	}
+	return normalized
+}
+
+// DefaultProbe sets the fields the API server defaults on probe.
+func DefaultProbe(probe *corev1.Probe) {
+	if probe == nil {
+		return
+	}
+	if probe.TimeoutSeconds == 0 {
+		probe.TimeoutSeconds = 1
+	}
+	if probe.PeriodSeconds == 0 {
+		probe.PeriodSeconds = 10
+	}
+	if probe.SuccessThreshold == 0 {
+		probe.SuccessThreshold = 1
+	}
+	if probe.FailureThreshold == 0 {
+		probe.FailureThreshold = 3
+	}
+	if probe.HTTPGet != nil && probe.HTTPGet.Scheme == "" {
+		probe.HTTPGet.Scheme = corev1.URISchemeHTTP
+	}
+}
+
+// tolerationsEqual checks if current and expected contain the same
+// tolerations, ignoring their order. Tolerations are matched as a set, so
+// reordering them in the Contour spec doesn't roll the pods.
+func tolerationsEqual(current, expected []corev1.Toleration) bool {
+	if len(current) != len(expected) {
+		return false
+	}
+	matched := make([]bool, len(current))
+	for _, e := range expected {
+		found := false
+		for i, c := range current {
+			if !matched[i] && apiequality.Semantic.DeepEqual(c, e) {
+				matched[i] = true
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
 // DeploymentSelectorsDiffer checks if the current and expected Deployment selectors differ.
 func DeploymentSelectorsDiffer(current, expected *appsv1.Deployment) bool {
 	return !apiequality.Semantic.DeepEqual(current.Spec.Selector, expected.Spec.Selector)
 }
 
+// PodDisruptionBudgetChanged checks if current and expected
+// PodDisruptionBudget match, and if not, returns the updated resource.
+func PodDisruptionBudgetChanged(current, expected *policyv1.PodDisruptionBudget) (*policyv1.PodDisruptionBudget, bool) {
+	changed := false
+	updated := current.DeepCopy()
+
+	if !apiequality.Semantic.DeepEqual(current.Labels, expected.Labels) {
+		updated.Labels = expected.Labels
+		changed = true
+	}
+
+	if !apiequality.Semantic.DeepEqual(current.Spec.Selector, expected.Spec.Selector) {
+		updated.Spec.Selector = expected.Spec.Selector
+		changed = true
+	}
+
+	if !apiequality.Semantic.DeepEqual(current.Spec.MinAvailable, expected.Spec.MinAvailable) {
+		updated.Spec.MinAvailable = expected.Spec.MinAvailable
+		changed = true
+	}
+
+	if !apiequality.Semantic.DeepEqual(current.Spec.MaxUnavailable, expected.Spec.MaxUnavailable) {
+		updated.Spec.MaxUnavailable = expected.Spec.MaxUnavailable
+		changed = true
+	}
+
+	if !changed {
+		return nil, false
+	}
+
+	return updated, true
+}
+
+// HorizontalPodAutoscalerChanged checks if current and expected
+// HorizontalPodAutoscaler match, and if not, returns the updated resource.
+// Scaling behavior is not compared as it's not managed by the operator.
+func HorizontalPodAutoscalerChanged(current, expected *autoscalingv2.HorizontalPodAutoscaler) (*autoscalingv2.HorizontalPodAutoscaler, bool) {
+	changed := false
+	updated := current.DeepCopy()
+
+	if !apiequality.Semantic.DeepEqual(current.Labels, expected.Labels) {
+		updated.Labels = expected.Labels
+		changed = true
+	}
+
+	if !apiequality.Semantic.DeepEqual(current.Spec.ScaleTargetRef, expected.Spec.ScaleTargetRef) {
+		updated.Spec.ScaleTargetRef = expected.Spec.ScaleTargetRef
+		changed = true
+	}
+
+	if !apiequality.Semantic.DeepEqual(current.Spec.MinReplicas, expected.Spec.MinReplicas) {
+		updated.Spec.MinReplicas = expected.Spec.MinReplicas
+		changed = true
+	}
+
+	if current.Spec.MaxReplicas != expected.Spec.MaxReplicas {
+		updated.Spec.MaxReplicas = expected.Spec.MaxReplicas
+		changed = true
+	}
+
+	if !apiequality.Semantic.DeepEqual(current.Spec.Metrics, expected.Spec.Metrics) {
+		updated.Spec.Metrics = expected.Spec.Metrics
+		changed = true
+	}
+
+	if !changed {
+		return nil, false
+	}
+
+	return updated, true
+}
+
 // ClusterIPServiceChanged checks if the spec of current and expected match and if not,
 // returns true and the expected Service resource. The cluster IP is not compared
 // as it's assumed to be dynamically assigned.
@@ -152,11 +300,48 @@ func ClusterIPServiceChanged(current, expected *corev1.Service) (*corev1.Service
 		changed = true
 	}
 
+	if !apiequality.Semantic.DeepEqual(current.Spec.SessionAffinityConfig, expected.Spec.SessionAffinityConfig) {
+		updated.Spec.SessionAffinityConfig = expected.Spec.SessionAffinityConfig
+		changed = true
+	}
+
 	if !apiequality.Semantic.DeepEqual(current.Spec.Type, expected.Spec.Type) {
 		updated.Spec.Type = expected.Spec.Type
 		changed = true
 	}
 
+	if ipFamiliesChanged(current, expected) {
+		updated.Spec.IPFamilyPolicy = expected.Spec.IPFamilyPolicy
+		updated.Spec.IPFamilies = expected.Spec.IPFamilies
+		changed = true
+	}
+
+	if !changed {
+		return nil, false
+	}
+
+	return updated, true
+}
+
+// MetricsServiceChanged checks if current and expected metrics Service match
+// and if not, returns true and the updated Service. Unlike ClusterIPServiceChanged,
+// labels and annotations are compared since they carry the scrape configuration.
+func MetricsServiceChanged(current, expected *corev1.Service) (*corev1.Service, bool) {
+	updated, changed := ClusterIPServiceChanged(current, expected)
+	if !changed {
+		updated = current.DeepCopy()
+	}
+
+	if !apiequality.Semantic.DeepEqual(current.Labels, expected.Labels) {
+		updated.Labels = expected.Labels
+		changed = true
+	}
+
+	if !apiequality.Semantic.DeepEqual(current.Annotations, expected.Annotations) {
+		updated.Annotations = expected.Annotations
+		changed = true
+	}
+
 	if !changed {
 		return nil, false
 	}
@@ -165,8 +350,9 @@ func ClusterIPServiceChanged(current, expected *corev1.Service) (*corev1.Service
 }
 
 // LoadBalancerServiceChanged checks if current and expected match and if not, returns
-// true and the expected Service resource. The healthCheckNodePort and a port's nodePort
-// are not compared since they are dynamically assigned.
+// true and the expected Service resource. A port's nodePort is not compared since it's
+// dynamically assigned, and the healthCheckNodePort is only compared when expected
+// pins it.
 func LoadBalancerServiceChanged(current, expected *corev1.Service) (*corev1.Service, bool) {
 	changed := false
 	updated := current.DeepCopy()
@@ -193,6 +379,10 @@ func LoadBalancerServiceChanged(current, expected *corev1.Service) (*corev1.Serv
 				updated.Spec.Ports[i].TargetPort = expected.Spec.Ports[i].TargetPort
 				changed = true
 			}
+			if !apiequality.Semantic.DeepEqual(p.AppProtocol, expected.Spec.Ports[i].AppProtocol) {
+				updated.Spec.Ports[i].AppProtocol = expected.Spec.Ports[i].AppProtocol
+				changed = true
+			}
 		}
 	}
 
@@ -211,11 +401,22 @@ func LoadBalancerServiceChanged(current, expected *corev1.Service) (*corev1.Serv
 		changed = true
 	}
 
+	if !apiequality.Semantic.DeepEqual(current.Spec.SessionAffinityConfig, expected.Spec.SessionAffinityConfig) {
+		updated.Spec.SessionAffinityConfig = expected.Spec.SessionAffinityConfig
+		changed = true
+	}
+
 	if !apiequality.Semantic.DeepEqual(current.Spec.Type, expected.Spec.Type) {
 		updated.Spec.Type = expected.Spec.Type
 		changed = true
 	}
 
+	if ipFamiliesChanged(current, expected) {
+		updated.Spec.IPFamilyPolicy = expected.Spec.IPFamilyPolicy
+		updated.Spec.IPFamilies = expected.Spec.IPFamilies
+		changed = true
+	}
+
 	if !apiequality.Semantic.DeepEqual(current.Annotations, expected.Annotations) {
 		updated.Annotations = expected.Annotations
 		changed = true
@@ -226,6 +427,23 @@ func LoadBalancerServiceChanged(current, expected *corev1.Service) (*corev1.Serv
 		changed = true
 	}
 
+	// The healthCheckNodePort is assigned by the API server unless expected
+	// pins it, so an assigned port is preserved.
+	if expected.Spec.HealthCheckNodePort != 0 &&
+		current.Spec.HealthCheckNodePort != expected.Spec.HealthCheckNodePort {
+		updated.Spec.HealthCheckNodePort = expected.Spec.HealthCheckNodePort
+		changed = true
+	}
+
+	// The API server defaults allocateLoadBalancerNodePorts, so only compare
+	// it when expected sets it. The loadBalancerClass can't be changed once set,
+	// see LoadBalancerClassChanged.
+	if expected.Spec.AllocateLoadBalancerNodePorts != nil &&
+		!apiequality.Semantic.DeepEqual(current.Spec.AllocateLoadBalancerNodePorts, expected.Spec.AllocateLoadBalancerNodePorts) {
+		updated.Spec.AllocateLoadBalancerNodePorts = expected.Spec.AllocateLoadBalancerNodePorts
+		changed = true
+	}
+
 	if !changed {
 		return nil, false
 	}
@@ -233,6 +451,15 @@ func LoadBalancerServiceChanged(current, expected *corev1.Service) (*corev1.Serv
 	return updated, true
 }
 
+// LoadBalancerClassChanged returns true if expected sets a loadBalancerClass
+// that doesn't match current. The class is immutable, so the Service must be
+// recreated to change it. Classes set on current by other controllers are
+// preserved when expected doesn't set one.
+func LoadBalancerClassChanged(current, expected *corev1.Service) bool {
+	return expected.Spec.LoadBalancerClass != nil &&
+		!apiequality.Semantic.DeepEqual(current.Spec.LoadBalancerClass, expected.Spec.LoadBalancerClass)
+}
+
 // NodePortServiceChanged checks if current and expected match and if not, returns
 // true and the expected Service resource. The healthCheckNodePort is not compared
 // since it's dynamically assigned.
@@ -267,11 +494,22 @@ func NodePortServiceChanged(current, expected *corev1.Service) (*corev1.Service,
 		changed = true
 	}
 
+	if !apiequality.Semantic.DeepEqual(current.Spec.SessionAffinityConfig, expected.Spec.SessionAffinityConfig) {
+		updated.Spec.SessionAffinityConfig = expected.Spec.SessionAffinityConfig
+		changed = true
+	}
+
 	if !apiequality.Semantic.DeepEqual(current.Spec.Type, expected.Spec.Type) {
 		updated.Spec.Type = expected.Spec.Type
 		changed = true
 	}
 
+	if ipFamiliesChanged(current, expected) {
+		updated.Spec.IPFamilyPolicy = expected.Spec.IPFamilyPolicy
+		updated.Spec.IPFamilies = expected.Spec.IPFamilies
+		changed = true
+	}
+
 	if !apiequality.Semantic.DeepEqual(current.Annotations, expected.Annotations) {
 		updated.Annotations = expected.Annotations
 		changed = true
@@ -284,6 +522,21 @@ func NodePortServiceChanged(current, expected *corev1.Service) (*corev1.Service,
 	return updated, true
 }
 
+// ipFamiliesChanged returns true if the IP family policy or IP families of
+// current and expected differ. Fields unset in expected are not compared
+// since the API server assigns them a default value.
+func ipFamiliesChanged(current, expected *corev1.Service) bool {
+	if expected.Spec.IPFamilyPolicy != nil &&
+		!apiequality.Semantic.DeepEqual(current.Spec.IPFamilyPolicy, expected.Spec.IPFamilyPolicy) {
+		return true
+	}
+	if len(expected.Spec.IPFamilies) > 0 &&
+		!apiequality.Semantic.DeepEqual(current.Spec.IPFamilies, expected.Spec.IPFamilies) {
+		return true
+	}
+	return false
+}
+
 // ContourStatusChanged checks if current and expected match and if not,
 // returns true.
 func ContourStatusChanged(current, expected operatorv1alpha1.ContourStatus) bool {
@@ -291,10 +544,22 @@ func ContourStatusChanged(current, expected operatorv1alpha1.ContourStatus) bool
 		return true
 	}
 
+	if current.Replicas != expected.Replicas {
+		return true
+	}
+
+	if current.Selector != expected.Selector {
+		return true
+	}
+
 	if current.AvailableEnvoys != expected.AvailableEnvoys {
 		return true
 	}
 
+	if current.Hostname != expected.Hostname {
+		return true
+	}
+
 	if !apiequality.Semantic.DeepEqual(current.Conditions, expected.Conditions) {
 		return true
 	}
@@ -302,14 +567,19 @@ func ContourStatusChanged(current, expected operatorv1alpha1.ContourStatus) bool
 	return false
 }
 
-// NamespaceConfigChanged checks if the current and expected Namespace match
-// and if not, returns true and the expected Namespace.
-func NamespaceConfigChanged(current, expected *corev1.Namespace) (*corev1.Namespace, bool) {
+// SecretChanged checks if current and expected Secret match, and if not,
+// returns true and the updated Secret.
+func SecretChanged(current, expected *corev1.Secret) (*corev1.Secret, bool) {
 	changed := false
 	updated := current.DeepCopy()
 
 	if !apiequality.Semantic.DeepEqual(current.Labels, expected.Labels) {
-		updated = expected
+		updated.Labels = expected.Labels
+		changed = true
+	}
+
+	if !apiequality.Semantic.DeepEqual(current.Data, expected.Data) {
+		updated.Data = expected.Data
 		changed = true
 	}
 
@@ -320,6 +590,75 @@ func NamespaceConfigChanged(current, expected *corev1.Namespace) (*corev1.Namesp
 	return updated, true
 }
 
+// NamespaceConfigChanged checks if the current Namespace contains the labels
+// and annotations of the expected Namespace and if not, returns true and the
+// current Namespace updated with them. Labels and annotations the operator
+// applied to current, as recorded by its managed keys annotations, are removed
+// once expected no longer sets them. Other labels and annotations, e.g. those
+// added by the API server or by users, are preserved.
+func NamespaceConfigChanged(current, expected *corev1.Namespace) (*corev1.Namespace, bool) {
+	changed := false
+	updated := current.DeepCopy()
+
+	for _, k := range managedKeys(current, operatorv1alpha1.ManagedNamespaceLabelsAnnotation) {
+		if _, found := expected.Labels[k]; found {
+			continue
+		}
+		if _, found := updated.Labels[k]; found {
+			delete(updated.Labels, k)
+			changed = true
+		}
+	}
+
+	for _, k := range append(managedKeys(current, operatorv1alpha1.ManagedNamespaceAnnotationsAnnotation),
+		operatorv1alpha1.ManagedNamespaceLabelsAnnotation, operatorv1alpha1.ManagedNamespaceAnnotationsAnnotation) {
+		if _, found := expected.Annotations[k]; found {
+			continue
+		}
+		if _, found := updated.Annotations[k]; found {
+			delete(updated.Annotations, k)
+			changed = true
+		}
+	}
+
+	for k, v := range expected.Labels {
+		if cur, found := current.Labels[k]; !found || cur != v {
+			if updated.Labels == nil {
+				updated.Labels = map[string]string{}
+			}
+			updated.Labels[k] = v
+			changed = true
+		}
+	}
+
+	for k, v := range expected.Annotations {
+		if cur, found := current.Annotations[k]; !found || cur != v {
+			if updated.Annotations == nil {
+				updated.Annotations = map[string]string{}
+			}
+			updated.Annotations[k] = v
+			changed = true
+		}
+	}
+
+	if !changed {
+		return nil, false
+	}
+
+	return updated, true
+}
+
+// managedKeys returns the keys recorded in annotation of obj.
+func managedKeys(obj *corev1.Namespace, annotation string) []string {
+	var keys []string
+	for _, k := range strings.Split(obj.Annotations[annotation], ",") {
+		if k != "" {
+			keys = append(keys, k)
+		}
+	}
+	return keys
+}
+
 // ServiceAccountConfigChanged checks if the current and expected ServiceAccount
 // match and if not, returns true and the expected ServiceAccount.
 func ServiceAccountConfigChanged(current, expected *corev1.ServiceAccount) (*corev1.ServiceAccount, bool) {
@@ -338,6 +677,29 @@ func ServiceAccountConfigChanged(current, expected *corev1.ServiceAccount) (*cor
 	return updated, true
 }
 
+// IngressClassConfigChanged checks if the current and expected IngressClass
+// match and if not, returns true and the expected IngressClass.
+func IngressClassConfigChanged(current, expected *networkingv1.IngressClass) (*networkingv1.IngressClass, bool) {
+	changed := false
+	updated := current.DeepCopy()
+
+	if !apiequality.Semantic.DeepEqual(current.Labels, expected.Labels) {
+		changed = true
+		updated.Labels = expected.Labels
+	}
+
+	if !apiequality.Semantic.DeepEqual(current.Spec, expected.Spec) {
+		changed = true
+		updated.Spec = expected.Spec
+	}
+
+	if !changed {
+		return nil, false
+	}
+
+	return updated, true
+}
+
 // ClusterRoleConfigChanged checks if the current and expected ClusterRole
 // match and if not, returns true and the expected ClusterRole.
 func ClusterRoleConfigChanged(current, expected *rbacv1.ClusterRole) (*rbacv1.ClusterRole, bool) {
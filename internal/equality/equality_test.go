@@ -20,14 +20,23 @@ import (
 	"github.com/projectcontour/contour-operator/internal/equality"
 	objds "github.com/projectcontour/contour-operator/internal/objects/daemonset"
 	objdeploy "github.com/projectcontour/contour-operator/internal/objects/deployment"
+	objhpa "github.com/projectcontour/contour-operator/internal/objects/hpa"
+	objic "github.com/projectcontour/contour-operator/internal/objects/ingressclass"
 	objjob "github.com/projectcontour/contour-operator/internal/objects/job"
+	objpdb "github.com/projectcontour/contour-operator/internal/objects/pdb"
 	objsvc "github.com/projectcontour/contour-operator/internal/objects/service"
 
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/pointer"
 )
 
 var (
@@ -96,6 +105,20 @@ func TestDaemonSetConfigChanged(t *testing.T) {
 			},
 			expect: true,
 		},
+		{
+			description: "if priority class is changed",
+			mutate: func(ds *appsv1.DaemonSet) {
+				ds.Spec.Template.Spec.PriorityClassName = "system-node-critical"
+			},
+			expect: true,
+		},
+		{
+			description: "if update strategy is changed",
+			mutate: func(ds *appsv1.DaemonSet) {
+				ds.Spec.UpdateStrategy = appsv1.DaemonSetUpdateStrategy{Type: appsv1.OnDeleteDaemonSetStrategyType}
+			},
+			expect: true,
+		},
 		{
 			description: "if container args are changed",
 			mutate: func(ds *appsv1.DaemonSet) {
@@ -297,6 +320,36 @@ func TestDeploymentConfigChanged(t *testing.T) {
 			},
 			expect: true,
 		},
+		{
+			description: "if priority class is changed",
+			mutate: func(deploy *appsv1.Deployment) {
+				deploy.Spec.Template.Spec.PriorityClassName = "system-cluster-critical"
+			},
+			expect: true,
+		},
+		{
+			description: "if topology spread constraints are changed",
+			mutate: func(deploy *appsv1.Deployment) {
+				deploy.Spec.Template.Spec.TopologySpreadConstraints = []corev1.TopologySpreadConstraint{
+					{
+						MaxSkew:           1,
+						TopologyKey:       "topology.kubernetes.io/zone",
+						WhenUnsatisfiable: corev1.ScheduleAnyway,
+						LabelSelector:     objdeploy.ContourDeploymentPodSelector(),
+					},
+				}
+			},
+			expect: true,
+		},
+		{
+			description: "if affinity is changed",
+			mutate: func(deploy *appsv1.Deployment) {
+				deploy.Spec.Template.Spec.Affinity = &corev1.Affinity{
+					NodeAffinity: &corev1.NodeAffinity{},
+				}
+			},
+			expect: true,
+		},
 		{
 			description: "if container commands are changed",
 			mutate: func(deploy *appsv1.Deployment) {
@@ -343,6 +396,333 @@ func TestDeploymentConfigChanged(t *testing.T) {
 	}
 }
 
+func TestTolerationOrder(t *testing.T) {
+	tolerations := []corev1.Toleration{
+		{Key: "dedicated", Operator: corev1.TolerationOpEqual, Value: "ingress", Effect: corev1.TaintEffectNoSchedule},
+		{Key: "node.kubernetes.io/not-ready", Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoExecute, TolerationSeconds: pointer.Int64Ptr(300)},
+	}
+	reordered := []corev1.Toleration{tolerations[1], tolerations[0]}
+
+	testCases := []struct {
+		description string
+		current     []corev1.Toleration
+		expected    []corev1.Toleration
+		expect      bool
+	}{
+		{
+			description: "if tolerations are reordered",
+			current:     tolerations,
+			expected:    reordered,
+			expect:      false,
+		},
+		{
+			description: "if a toleration is removed",
+			current:     tolerations,
+			expected:    tolerations[:1],
+			expect:      true,
+		},
+		{
+			description: "if a toleration is duplicated",
+			current:     []corev1.Toleration{tolerations[0], tolerations[0]},
+			expected:    tolerations,
+			expect:      true,
+		},
+		{
+			description: "if toleration seconds are changed",
+			current:     tolerations,
+			expected: []corev1.Toleration{
+				tolerations[0],
+				{Key: "node.kubernetes.io/not-ready", Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoExecute, TolerationSeconds: pointer.Int64Ptr(60)},
+			},
+			expect: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		current := objdeploy.DesiredDeployment(cntr, testImage)
+		current.Spec.Template.Spec.Tolerations = tc.current
+		expected := current.DeepCopy()
+		expected.Spec.Template.Spec.Tolerations = tc.expected
+		if _, changed := equality.DeploymentConfigChanged(current, expected); changed != tc.expect {
+			t.Errorf("%s, expect deploymentConfigChanged to be %t, got %t", tc.description, tc.expect, changed)
+		}
+
+		currentDs := objds.DesiredDaemonSet(cntr, testImage, testImage)
+		currentDs.Spec.Template.Spec.Tolerations = tc.current
+		expectedDs := currentDs.DeepCopy()
+		expectedDs.Spec.Template.Spec.Tolerations = tc.expected
+		if _, changed := equality.DaemonsetConfigChanged(currentDs, expectedDs); changed != tc.expect {
+			t.Errorf("%s, expect daemonsetConfigChanged to be %t, got %t", tc.description, tc.expect, changed)
+		}
+	}
+}
+
+func TestServerDefaultedPodTemplate(t *testing.T) {
+	testCases := []struct {
+		description string
+		mutate      func(expected *corev1.PodSpec)
+		expect      bool
+	}{
+		{
+			description: "if probe defaults are left unset",
+			mutate: func(spec *corev1.PodSpec) {
+				for i := range spec.Containers {
+					for _, probe := range []*corev1.Probe{spec.Containers[i].LivenessProbe, spec.Containers[i].ReadinessProbe} {
+						if probe == nil {
+							continue
+						}
+						if probe.TimeoutSeconds == 1 {
+							probe.TimeoutSeconds = 0
+						}
+						if probe.PeriodSeconds == 10 {
+							probe.PeriodSeconds = 0
+						}
+						if probe.SuccessThreshold == 1 {
+							probe.SuccessThreshold = 0
+						}
+						if probe.FailureThreshold == 3 {
+							probe.FailureThreshold = 0
+						}
+						if probe.HTTPGet != nil && probe.HTTPGet.Scheme == corev1.URISchemeHTTP {
+							probe.HTTPGet.Scheme = ""
+						}
+					}
+				}
+			},
+			expect: false,
+		},
+		{
+			description: "if a probe differs from the defaults",
+			mutate: func(spec *corev1.PodSpec) {
+				for i := range spec.Containers {
+					if probe := spec.Containers[i].ReadinessProbe; probe != nil {
+						probe.PeriodSeconds = 0
+						probe.FailureThreshold = 5
+					}
+				}
+			},
+			expect: true,
+		},
+		{
+			description: "if a quantity is written in another unit",
+			mutate: func(spec *corev1.PodSpec) {
+				spec.Containers[0].Resources.Requests = corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")}
+			},
+			expect: false,
+		},
+		{
+			description: "if a quantity is changed",
+			mutate: func(spec *corev1.PodSpec) {
+				spec.Containers[0].Resources.Requests = corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m")}
+			},
+			expect: true,
+		},
+	}
+
+	requests := corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1000m")}
+	for _, tc := range testCases {
+		current := objdeploy.DesiredDeployment(cntr, testImage)
+		current.Spec.Template.Spec.Containers[0].Resources.Requests = requests
+		expected := current.DeepCopy()
+		tc.mutate(&expected.Spec.Template.Spec)
+		if _, changed := equality.DeploymentConfigChanged(current, expected); changed != tc.expect {
+			t.Errorf("%s, expect deploymentConfigChanged to be %t, got %t", tc.description, tc.expect, changed)
+		}
+
+		currentDs := objds.DesiredDaemonSet(cntr, testImage, testImage)
+		currentDs.Spec.Template.Spec.Containers[0].Resources.Requests = requests
+		expectedDs := currentDs.DeepCopy()
+		tc.mutate(&expectedDs.Spec.Template.Spec)
+		if _, changed := equality.DaemonsetConfigChanged(currentDs, expectedDs); changed != tc.expect {
+			t.Errorf("%s, expect daemonsetConfigChanged to be %t, got %t", tc.description, tc.expect, changed)
+		}
+	}
+}
+
+func TestDiff(t *testing.T) {
+	testCases := []struct {
+		description string
+		mutate      func(deploy *appsv1.Deployment)
+		expect      []string
+	}{
+		{
+			description: "if nothing changed",
+			mutate:      func(_ *appsv1.Deployment) {},
+			expect:      nil,
+		},
+		{
+			description: "if the container image changed",
+			mutate: func(deploy *appsv1.Deployment) {
+				deploy.Spec.Template.Spec.Containers[0].Image = "foo:latest"
+			},
+			expect: []string{"spec.template.spec.containers[0].image"},
+		},
+		{
+			description: "if replicas and a pod label changed",
+			mutate: func(deploy *appsv1.Deployment) {
+				deploy.Spec.Replicas = pointer.Int32Ptr(5)
+				deploy.Spec.Template.Labels["foo"] = "bar"
+			},
+			expect: []string{"spec.replicas", "spec.template.metadata.labels[foo]"},
+		},
+		{
+			description: "if a quantity changed",
+			mutate: func(deploy *appsv1.Deployment) {
+				deploy.Spec.Template.Spec.Containers[0].Resources.Limits = corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")}
+			},
+			expect: []string{"spec.template.spec.containers[0].resources.limits[memory]"},
+		},
+		{
+			description: "if a container was added",
+			mutate: func(deploy *appsv1.Deployment) {
+				deploy.Spec.Template.Spec.Containers = append(deploy.Spec.Template.Spec.Containers, corev1.Container{Name: "foo"})
+			},
+			expect: []string{"spec.template.spec.containers"},
+		},
+	}
+
+	for _, tc := range testCases {
+		current := objdeploy.DesiredDeployment(cntr, testImage)
+		expected := current.DeepCopy()
+		tc.mutate(expected)
+		if diff := equality.Diff(current, expected); !apiequality.Semantic.DeepEqual(diff, tc.expect) {
+			t.Errorf("%s, expect Diff to be %v, got %v", tc.description, tc.expect, diff)
+		}
+	}
+}
+
+func TestHorizontalPodAutoscalerChanged(t *testing.T) {
+	c := cntr.DeepCopy()
+	c.Spec.Envoy = &operatorv1alpha1.EnvoySettings{
+		WorkloadType: operatorv1alpha1.DeploymentEnvoyWorkload,
+		Autoscaling: &operatorv1alpha1.EnvoyAutoscaling{
+			MaxReplicas: 10,
+		},
+	}
+
+	testCases := []struct {
+		description string
+		mutate      func(hpa *autoscalingv2.HorizontalPodAutoscaler)
+		expect      bool
+	}{
+		{
+			description: "if nothing changes",
+			mutate:      func(_ *autoscalingv2.HorizontalPodAutoscaler) {},
+			expect:      false,
+		},
+		{
+			description: "if min replicas is changed",
+			mutate: func(hpa *autoscalingv2.HorizontalPodAutoscaler) {
+				hpa.Spec.MinReplicas = pointer.Int32Ptr(3)
+			},
+			expect: true,
+		},
+		{
+			description: "if max replicas is changed",
+			mutate: func(hpa *autoscalingv2.HorizontalPodAutoscaler) {
+				hpa.Spec.MaxReplicas = 20
+			},
+			expect: true,
+		},
+		{
+			description: "if the scale target is changed",
+			mutate: func(hpa *autoscalingv2.HorizontalPodAutoscaler) {
+				hpa.Spec.ScaleTargetRef.Name = "foo"
+			},
+			expect: true,
+		},
+		{
+			description: "if metrics are changed",
+			mutate: func(hpa *autoscalingv2.HorizontalPodAutoscaler) {
+				hpa.Spec.Metrics[0].Resource.Target.AverageUtilization = pointer.Int32Ptr(50)
+			},
+			expect: true,
+		},
+		{
+			description: "if scaling behavior is defaulted",
+			mutate: func(hpa *autoscalingv2.HorizontalPodAutoscaler) {
+				hpa.Spec.Behavior = &autoscalingv2.HorizontalPodAutoscalerBehavior{}
+			},
+			expect: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		expected := objhpa.DesiredEnvoyHPA(c)
+		mutated := expected.DeepCopy()
+		tc.mutate(mutated)
+		if updated, changed := equality.HorizontalPodAutoscalerChanged(mutated, expected); changed != tc.expect {
+			t.Errorf("%s, expect HorizontalPodAutoscalerChanged to be %t, got %t", tc.description, tc.expect, changed)
+		} else if changed {
+			if _, changedAgain := equality.HorizontalPodAutoscalerChanged(updated, expected); changedAgain {
+				t.Errorf("%s, HorizontalPodAutoscalerChanged does not behave as a fixed point function", tc.description)
+			}
+		}
+	}
+}
+
+func TestPodDisruptionBudgetChanged(t *testing.T) {
+	c := cntr.DeepCopy()
+	c.Spec.Contour = &operatorv1alpha1.ContourSettings{
+		PodDisruptionBudget: &operatorv1alpha1.PodDisruptionBudgetSettings{},
+	}
+
+	testCases := []struct {
+		description string
+		mutate      func(pdb *policyv1.PodDisruptionBudget)
+		expect      bool
+	}{
+		{
+			description: "if nothing changes",
+			mutate:      func(_ *policyv1.PodDisruptionBudget) {},
+			expect:      false,
+		},
+		{
+			description: "if max unavailable is changed",
+			mutate: func(pdb *policyv1.PodDisruptionBudget) {
+				maxUnavailable := intstr.FromString("50%")
+				pdb.Spec.MaxUnavailable = &maxUnavailable
+			},
+			expect: true,
+		},
+		{
+			description: "if min available is set",
+			mutate: func(pdb *policyv1.PodDisruptionBudget) {
+				minAvailable := intstr.FromInt(1)
+				pdb.Spec.MinAvailable = &minAvailable
+			},
+			expect: true,
+		},
+		{
+			description: "if the selector is changed",
+			mutate: func(pdb *policyv1.PodDisruptionBudget) {
+				pdb.Spec.Selector = objds.EnvoyDaemonSetPodSelector()
+			},
+			expect: true,
+		},
+		{
+			description: "if labels are changed",
+			mutate: func(pdb *policyv1.PodDisruptionBudget) {
+				pdb.Labels = map[string]string{"foo": "bar"}
+			},
+			expect: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		expected := objpdb.DesiredContourPDB(c)
+		mutated := expected.DeepCopy()
+		tc.mutate(mutated)
+		if updated, changed := equality.PodDisruptionBudgetChanged(mutated, expected); changed != tc.expect {
+			t.Errorf("%s, expect PodDisruptionBudgetChanged to be %t, got %t", tc.description, tc.expect, changed)
+		} else if changed {
+			if _, changedAgain := equality.PodDisruptionBudgetChanged(updated, expected); changedAgain {
+				t.Errorf("%s, PodDisruptionBudgetChanged does not behave as a fixed point function", tc.description)
+			}
+		}
+	}
+}
+
 func TestClusterIpServiceChanged(t *testing.T) {
 	testCases := []struct {
 		description string
@@ -383,6 +763,13 @@ func TestClusterIpServiceChanged(t *testing.T) {
 			},
 			expect: true,
 		},
+		{
+			description: "if the port app protocol changed",
+			mutate: func(svc *corev1.Service) {
+				svc.Spec.Ports[0].AppProtocol = pointer.StringPtr("kubernetes.io/h2c")
+			},
+			expect: true,
+		},
 		{
 			description: "if ports are added",
 			mutate: func(svc *corev1.Service) {
@@ -448,6 +835,79 @@ func TestClusterIpServiceChanged(t *testing.T) {
 	}
 }
 
+func TestMetricsServiceChanged(t *testing.T) {
+	testCases := []struct {
+		description string
+		mutate      func(service *corev1.Service)
+		expect      bool
+	}{
+		{
+			description: "if nothing changed",
+			mutate:      func(_ *corev1.Service) {},
+			expect:      false,
+		},
+		{
+			description: "if the port number changed",
+			mutate: func(svc *corev1.Service) {
+				svc.Spec.Ports[0].Port = int32(1234)
+			},
+			expect: true,
+		},
+		{
+			description: "if the scrape annotation was removed",
+			mutate: func(svc *corev1.Service) {
+				delete(svc.Annotations, "prometheus.io/scrape")
+			},
+			expect: true,
+		},
+		{
+			description: "if the scrape port annotation changed",
+			mutate: func(svc *corev1.Service) {
+				svc.Annotations["prometheus.io/port"] = "1234"
+			},
+			expect: true,
+		},
+		{
+			description: "if an annotation was added",
+			mutate: func(svc *corev1.Service) {
+				svc.Annotations["foo"] = "bar"
+			},
+			expect: true,
+		},
+		{
+			description: "if the app label was removed",
+			mutate: func(svc *corev1.Service) {
+				delete(svc.Labels, "app.kubernetes.io/name")
+			},
+			expect: true,
+		},
+		{
+			description: "if the component label changed",
+			mutate: func(svc *corev1.Service) {
+				svc.Labels["app.kubernetes.io/component"] = "foo"
+			},
+			expect: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		for _, expected := range []*corev1.Service{
+			objsvc.DesiredContourMetricsService(cntr),
+			objsvc.DesiredEnvoyMetricsService(cntr),
+		} {
+			mutated := expected.DeepCopy()
+			tc.mutate(mutated)
+			if updated, changed := equality.MetricsServiceChanged(mutated, expected); changed != tc.expect {
+				t.Errorf("%s, expect MetricsServiceChanged to be %t, got %t", tc.description, tc.expect, changed)
+			} else if changed {
+				if _, changedAgain := equality.MetricsServiceChanged(updated, expected); changedAgain {
+					t.Errorf("%s, MetricsServiceChanged does not behave as a fixed point function", tc.description)
+				}
+			}
+		}
+	}
+}
+
 func TestLoadBalancerServiceChanged(t *testing.T) {
 	testCases := []struct {
 		description string
@@ -488,6 +948,13 @@ func TestLoadBalancerServiceChanged(t *testing.T) {
 			},
 			expect: true,
 		},
+		{
+			description: "if the port app protocol changed",
+			mutate: func(svc *corev1.Service) {
+				svc.Spec.Ports[0].AppProtocol = pointer.StringPtr("kubernetes.io/h2c")
+			},
+			expect: true,
+		},
 		{
 			description: "if ports are added",
 			mutate: func(svc *corev1.Service) {
@@ -536,6 +1003,16 @@ func TestLoadBalancerServiceChanged(t *testing.T) {
 			},
 			expect: true,
 		},
+		{
+			description: "if session affinity config changed",
+			mutate: func(svc *corev1.Service) {
+				timeout := int32(60)
+				svc.Spec.SessionAffinityConfig = &corev1.SessionAffinityConfig{
+					ClientIP: &corev1.ClientIPConfig{TimeoutSeconds: &timeout},
+				}
+			},
+			expect: true,
+		},
 		{
 			description: "if external traffic policy changed",
 			mutate: func(svc *corev1.Service) {
@@ -611,6 +1088,204 @@ func TestLoadBalancerServiceChanged(t *testing.T) {
 	}
 }
 
+func TestServiceIPFamiliesChanged(t *testing.T) {
+	dualStack := corev1.IPFamilyPolicyRequireDualStack
+	singleStack := corev1.IPFamilyPolicySingleStack
+
+	testCases := []struct {
+		description string
+		policy      *corev1.IPFamilyPolicyType
+		families    []corev1.IPFamily
+		mutate      func(service *corev1.Service)
+		expect      bool
+	}{
+		{
+			description: "if ip families are defaulted by the api server",
+			mutate: func(svc *corev1.Service) {
+				svc.Spec.IPFamilyPolicy = &singleStack
+				svc.Spec.IPFamilies = []corev1.IPFamily{corev1.IPv4Protocol}
+			},
+			expect: false,
+		},
+		{
+			description: "if ip family policy changed",
+			policy:      &dualStack,
+			mutate: func(svc *corev1.Service) {
+				svc.Spec.IPFamilyPolicy = &singleStack
+			},
+			expect: true,
+		},
+		{
+			description: "if ip families changed",
+			policy:      &dualStack,
+			families:    []corev1.IPFamily{corev1.IPv6Protocol, corev1.IPv4Protocol},
+			mutate: func(svc *corev1.Service) {
+				svc.Spec.IPFamilies = []corev1.IPFamily{corev1.IPv4Protocol, corev1.IPv6Protocol}
+			},
+			expect: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		c := cntr.DeepCopy()
+		c.Spec.NetworkPublishing.IPFamilyPolicy = tc.policy
+		c.Spec.NetworkPublishing.IPFamilies = tc.families
+
+		expected := objsvc.DesiredContourService(c)
+		mutated := expected.DeepCopy()
+		tc.mutate(mutated)
+		if updated, changed := equality.ClusterIPServiceChanged(mutated, expected); changed != tc.expect {
+			t.Errorf("%s, expect ClusterIPServiceChanged to be %t, got %t", tc.description, tc.expect, changed)
+		} else if changed {
+			if _, changedAgain := equality.ClusterIPServiceChanged(updated, expected); changedAgain {
+				t.Errorf("%s, ClusterIPServiceChanged does not behave as a fixed point function", tc.description)
+			}
+		}
+
+		expected = objsvc.DesiredEnvoyService(c)
+		mutated = expected.DeepCopy()
+		tc.mutate(mutated)
+		if updated, changed := equality.LoadBalancerServiceChanged(mutated, expected); changed != tc.expect {
+			t.Errorf("%s, expect LoadBalancerServiceChanged to be %t, got %t", tc.description, tc.expect, changed)
+		} else if changed {
+			if _, changedAgain := equality.LoadBalancerServiceChanged(updated, expected); changedAgain {
+				t.Errorf("%s, LoadBalancerServiceChanged does not behave as a fixed point function", tc.description)
+			}
+		}
+	}
+}
+
+func TestLoadBalancerServiceDefaultedFieldsChanged(t *testing.T) {
+	class := "example.com/lb"
+	otherClass := "example.com/other-lb"
+
+	testCases := []struct {
+		description   string
+		class         *string
+		allocatePorts *bool
+		healthCheck   *int32
+		mutate        func(service *corev1.Service)
+		expect        bool
+	}{
+		{
+			description: "if health check node port is assigned by the api server",
+			mutate: func(svc *corev1.Service) {
+				svc.Spec.HealthCheckNodePort = int32(31000)
+			},
+			expect: false,
+		},
+		{
+			description: "if health check node port changed",
+			healthCheck: pointer.Int32Ptr(32000),
+			mutate: func(svc *corev1.Service) {
+				svc.Spec.HealthCheckNodePort = int32(31000)
+			},
+			expect: true,
+		},
+		{
+			description: "if node port allocation is defaulted by the api server",
+			mutate: func(svc *corev1.Service) {
+				svc.Spec.AllocateLoadBalancerNodePorts = pointer.BoolPtr(true)
+			},
+			expect: false,
+		},
+		{
+			description:   "if node port allocation changed",
+			allocatePorts: pointer.BoolPtr(false),
+			mutate: func(svc *corev1.Service) {
+				svc.Spec.AllocateLoadBalancerNodePorts = pointer.BoolPtr(true)
+			},
+			expect: true,
+		},
+		{
+			description: "if load balancer class is unset",
+			mutate: func(svc *corev1.Service) {
+				svc.Spec.LoadBalancerClass = &otherClass
+			},
+			expect: false,
+		},
+		{
+			description: "if load balancer class changed",
+			class:       &class,
+			mutate: func(svc *corev1.Service) {
+				svc.Spec.LoadBalancerClass = &otherClass
+			},
+			expect: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		c := cntr.DeepCopy()
+		c.Spec.NetworkPublishing.Envoy.Type = operatorv1alpha1.LoadBalancerServicePublishingType
+		c.Spec.NetworkPublishing.Envoy.LoadBalancer.LoadBalancerClass = tc.class
+		c.Spec.NetworkPublishing.Envoy.LoadBalancer.AllocateLoadBalancerNodePorts = tc.allocatePorts
+		c.Spec.NetworkPublishing.Envoy.LoadBalancer.HealthCheckNodePort = tc.healthCheck
+
+		expected := objsvc.DesiredEnvoyService(c)
+		mutated := expected.DeepCopy()
+		tc.mutate(mutated)
+		if updated, changed := equality.LoadBalancerServiceChanged(mutated, expected); changed != tc.expect {
+			t.Errorf("%s, expect LoadBalancerServiceChanged to be %t, got %t", tc.description, tc.expect, changed)
+		} else if changed {
+			if _, changedAgain := equality.LoadBalancerServiceChanged(updated, expected); changedAgain {
+				t.Errorf("%s, LoadBalancerServiceChanged does not behave as a fixed point function", tc.description)
+			}
+		}
+	}
+}
+
+func TestLoadBalancerClassChanged(t *testing.T) {
+	class := "example.com/lb"
+	otherClass := "example.com/other-lb"
+
+	testCases := []struct {
+		description string
+		current     *string
+		expected    *string
+		expect      bool
+	}{
+		{
+			description: "if load balancer class is unset",
+			expect:      false,
+		},
+		{
+			description: "if load balancer class is set by another controller",
+			current:     &otherClass,
+			expect:      false,
+		},
+		{
+			description: "if load balancer class is unchanged",
+			current:     &class,
+			expected:    &class,
+			expect:      false,
+		},
+		{
+			description: "if load balancer class changed",
+			current:     &otherClass,
+			expected:    &class,
+			expect:      true,
+		},
+		{
+			description: "if load balancer class is added",
+			expected:    &class,
+			expect:      true,
+		},
+	}
+
+	for _, tc := range testCases {
+		c := cntr.DeepCopy()
+		c.Spec.NetworkPublishing.Envoy.Type = operatorv1alpha1.LoadBalancerServicePublishingType
+		c.Spec.NetworkPublishing.Envoy.LoadBalancer.LoadBalancerClass = tc.expected
+
+		expected := objsvc.DesiredEnvoyService(c)
+		current := expected.DeepCopy()
+		current.Spec.LoadBalancerClass = tc.current
+		if changed := equality.LoadBalancerClassChanged(current, expected); changed != tc.expect {
+			t.Errorf("%s, expect LoadBalancerClassChanged to be %t, got %t", tc.description, tc.expect, changed)
+		}
+	}
+}
+
 func TestNodePortServiceChanged(t *testing.T) {
 	testCases := []struct {
 		description string
@@ -661,6 +1336,180 @@ func TestNodePortServiceChanged(t *testing.T) {
 	}
 }
 
+func TestNamespaceConfigChanged(t *testing.T) {
+	testCases := []struct {
+		description string
+		mutate      func(ns *corev1.Namespace)
+		expect      bool
+	}{
+		{
+			description: "if nothing changes",
+			mutate:      func(_ *corev1.Namespace) {},
+			expect:      false,
+		},
+		{
+			description: "if an unmanaged label is added",
+			mutate: func(ns *corev1.Namespace) {
+				ns.Labels["kubernetes.io/metadata.name"] = ns.Name
+			},
+			expect: false,
+		},
+		{
+			description: "if a managed label is changed",
+			mutate: func(ns *corev1.Namespace) {
+				ns.Labels["pod-security.kubernetes.io/enforce"] = "privileged"
+			},
+			expect: true,
+		},
+		{
+			description: "if a managed annotation is removed",
+			mutate: func(ns *corev1.Namespace) {
+				ns.Annotations = nil
+			},
+			expect: true,
+		},
+		{
+			description: "if a previously managed label is no longer expected",
+			mutate: func(ns *corev1.Namespace) {
+				ns.Labels["pod-security.kubernetes.io/audit"] = "restricted"
+				ns.Annotations[operatorv1alpha1.ManagedNamespaceLabelsAnnotation] =
+					"pod-security.kubernetes.io/audit,pod-security.kubernetes.io/enforce"
+			},
+			expect: true,
+		},
+		{
+			description: "if a previously managed annotation is no longer expected",
+			mutate: func(ns *corev1.Namespace) {
+				ns.Annotations["example.com/owner"] = "platform"
+				ns.Annotations[operatorv1alpha1.ManagedNamespaceAnnotationsAnnotation] = "example.com/owner,linkerd.io/inject"
+			},
+			expect: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		expected := &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: testNs,
+				Labels: map[string]string{
+					"app.kubernetes.io/managed-by":       "contour-operator",
+					"pod-security.kubernetes.io/enforce": "baseline",
+				},
+				Annotations: map[string]string{
+					"linkerd.io/inject": "disabled",
+					operatorv1alpha1.ManagedNamespaceLabelsAnnotation:      "pod-security.kubernetes.io/enforce",
+					operatorv1alpha1.ManagedNamespaceAnnotationsAnnotation: "linkerd.io/inject",
+				},
+			},
+		}
+		mutated := expected.DeepCopy()
+		tc.mutate(mutated)
+		if updated, changed := equality.NamespaceConfigChanged(mutated, expected); changed != tc.expect {
+			t.Errorf("%s, expect NamespaceConfigChanged to be %t, got %t", tc.description, tc.expect, changed)
+		} else if changed {
+			if _, changedAgain := equality.NamespaceConfigChanged(updated, expected); changedAgain {
+				t.Errorf("%s, NamespaceConfigChanged does not behave as a fixed point function", tc.description)
+			}
+			if !apiequality.Semantic.DeepEqual(updated.Labels, expected.Labels) {
+				t.Errorf("%s, expected labels %v, got %v", tc.description, expected.Labels, updated.Labels)
+			}
+			if !apiequality.Semantic.DeepEqual(updated.Annotations, expected.Annotations) {
+				t.Errorf("%s, expected annotations %v, got %v", tc.description, expected.Annotations, updated.Annotations)
+			}
+		}
+	}
+}
+
+func TestSecretChanged(t *testing.T) {
+	testCases := []struct {
+		description string
+		mutate      func(secret *corev1.Secret)
+		expect      bool
+	}{
+		{
+			description: "if nothing changes",
+			mutate:      func(_ *corev1.Secret) {},
+			expect:      false,
+		},
+		{
+			description: "if data is changed",
+			mutate: func(secret *corev1.Secret) {
+				secret.Data[corev1.DockerConfigJsonKey] = []byte(`{"auths":{}}`)
+			},
+			expect: true,
+		},
+		{
+			description: "if labels are changed",
+			mutate: func(secret *corev1.Secret) {
+				secret.Labels = map[string]string{"foo": "bar"}
+			},
+			expect: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		expected := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "registry-creds",
+				Namespace: testNs,
+				Labels:    map[string]string{"app.kubernetes.io/managed-by": "contour-operator"},
+			},
+			Type: corev1.SecretTypeDockerConfigJson,
+			Data: map[string][]byte{corev1.DockerConfigJsonKey: []byte("{}")},
+		}
+		mutated := expected.DeepCopy()
+		tc.mutate(mutated)
+		if updated, changed := equality.SecretChanged(mutated, expected); changed != tc.expect {
+			t.Errorf("%s, expect SecretChanged to be %t, got %t", tc.description, tc.expect, changed)
+		} else if changed {
+			if _, changedAgain := equality.SecretChanged(updated, expected); changedAgain {
+				t.Errorf("%s, SecretChanged does not behave as a fixed point function", tc.description)
+			}
+		}
+	}
+}
+
+func TestIngressClassConfigChanged(t *testing.T) {
+	testCases := []struct {
+		description string
+		mutate      func(class *networkingv1.IngressClass)
+		expect      bool
+	}{
+		{
+			description: "if nothing changes",
+			mutate:      func(_ *networkingv1.IngressClass) {},
+			expect:      false,
+		},
+		{
+			description: "if the controller is changed",
+			mutate: func(class *networkingv1.IngressClass) {
+				class.Spec.Controller = "example.com/ingress-controller"
+			},
+			expect: true,
+		},
+		{
+			description: "if labels are changed",
+			mutate: func(class *networkingv1.IngressClass) {
+				class.Labels = map[string]string{"foo": "bar"}
+			},
+			expect: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		expected := objic.DesiredIngressClass(cntr, "contour")
+		mutated := expected.DeepCopy()
+		tc.mutate(mutated)
+		if updated, changed := equality.IngressClassConfigChanged(mutated, expected); changed != tc.expect {
+			t.Errorf("%s, expect IngressClassConfigChanged to be %t, got %t", tc.description, tc.expect, changed)
+		} else if changed {
+			if _, changedAgain := equality.IngressClassConfigChanged(updated, expected); changedAgain {
+				t.Errorf("%s, IngressClassConfigChanged does not behave as a fixed point function", tc.description)
+			}
+		}
+	}
+}
+
 func TestContourStatusChangedChanged(t *testing.T) {
 	testCases := []struct {
 		description string
@@ -682,6 +1531,22 @@ func TestContourStatusChangedChanged(t *testing.T) {
 			},
 			expect: true,
 		},
+		{
+			description: "if replicas changed",
+			current:     operatorv1alpha1.ContourStatus{},
+			mutate: func(status *operatorv1alpha1.ContourStatus) {
+				status.Replicas = int32(2)
+			},
+			expect: true,
+		},
+		{
+			description: "if selector changed",
+			current:     operatorv1alpha1.ContourStatus{},
+			mutate: func(status *operatorv1alpha1.ContourStatus) {
+				status.Selector = "app=contour"
+			},
+			expect: true,
+		},
 		{
 			description: "if available envoys changed",
 			current:     operatorv1alpha1.ContourStatus{},
@@ -690,6 +1555,14 @@ func TestContourStatusChangedChanged(t *testing.T) {
 			},
 			expect: true,
 		},
+		{
+			description: "if hostname changed",
+			current:     operatorv1alpha1.ContourStatus{},
+			mutate: func(status *operatorv1alpha1.ContourStatus) {
+				status.Hostname = "ingress.example.com"
+			},
+			expect: true,
+		},
 		{
 			description: "if a condition is added",
 			current:     operatorv1alpha1.ContourStatus{},
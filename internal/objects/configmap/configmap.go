@@ -16,11 +16,15 @@ package configmap
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"path/filepath"
 	"text/template"
 
 	operatorv1alpha1 "github.com/projectcontour/contour-operator/api/v1alpha1"
 	objcontour "github.com/projectcontour/contour-operator/internal/objects/contour"
+	objcfg "github.com/projectcontour/contour-operator/internal/objects/sharedconfig"
 	"github.com/projectcontour/contour-operator/pkg/labels"
 
 	corev1 "k8s.io/api/core/v1"
@@ -59,26 +63,31 @@ gateway:
 # "Tranfer-Encoding: chunked" is also set.
 # disableAllowChunkedLength: false
 # Disable HTTPProxy permitInsecure field
-disablePermitInsecure: false
+disablePermitInsecure: {{.DisablePermitInsecure}}
 tls:
-# minimum TLS version that Contour will negotiate
-# minimum-protocol-version: "1.2"
+# minimum TLS version that Contour will negotiate{{if .TLSMinimumProtocolVersion }}
+  minimum-protocol-version: "{{.TLSMinimumProtocolVersion}}"{{else}}
+# minimum-protocol-version: "1.2"{{end}}
 # TLS ciphers to be supported by Envoy TLS listeners when negotiating
-# TLS 1.2.
+# TLS 1.2.{{if .TLSCipherSuites }}
+  cipher-suites:{{range .TLSCipherSuites}}
+  - '{{.}}'{{end}}{{else}}
 # cipher-suites:
 # - '[ECDHE-ECDSA-AES128-GCM-SHA256|ECDHE-ECDSA-CHACHA20-POLY1305]'
 # - '[ECDHE-RSA-AES128-GCM-SHA256|ECDHE-RSA-CHACHA20-POLY1305]'
 # - 'ECDHE-ECDSA-AES256-GCM-SHA384'
-# - 'ECDHE-RSA-AES256-GCM-SHA384'
+# - 'ECDHE-RSA-AES256-GCM-SHA384'{{end}}
 # Defines the Kubernetes name/namespace matching a secret to use
 # as the fallback certificate when requests which don't match the
 # SNI defined for a vhost.
   fallback-certificate:
 #   name: fallback-secret-name
 #   namespace: projectcontour
-  envoy-client-certificate:
+  envoy-client-certificate:{{if .EnvoyClientCertificateName }}
+    name: {{.EnvoyClientCertificateName}}
+    namespace: {{.EnvoyClientCertificateNamespace}}{{else}}
 #   name: envoy-client-cert-secret-name
-#   namespace: projectcontour
+#   namespace: projectcontour{{end}}
 # The following config shows the defaults for the leader election.
 # leaderelection:
 #   configmap-name: leader-elect
@@ -92,14 +101,18 @@ enableExternalNameService: {{.EnableExternalNameService}}{{else}}
 # enableExternalNameService: false{{end}}
 ##
 ### Logging options
-# Default setting
-accesslog-format: envoy
+# Default setting{{if .AccessLogFormat }}
+accesslog-format: {{.AccessLogFormat}}{{else}}
+accesslog-format: envoy{{end}}{{if .AccessLogLevel }}
+accesslog-level: {{.AccessLogLevel}}{{end}}
 # To enable JSON logging in Envoy
 # accesslog-format: json
 # The default fields that will be logged are specified below.
 # To customize this list, just add or remove entries.
 # The canonical list is available at
-# https://godoc.org/github.com/projectcontour/contour/internal/envoy#JSONFields
+# https://godoc.org/github.com/projectcontour/contour/internal/envoy#JSONFields{{if .AccessLogJSONFields }}
+json-fields:{{range .AccessLogJSONFields}}
+  - {{printf "%q" .}}{{end}}{{else}}
 # json-fields:
 #   - "@timestamp"
 #   - "authority"
@@ -121,32 +134,116 @@ accesslog-format: envoy
 #   - "upstream_local_address"
 #   - "upstream_service_time"
 #   - "user_agent"
-#   - "x_forwarded_for"
-#
+#   - "x_forwarded_for"{{end}}
+#{{if .DefaultHTTPVersions }}
+default-http-versions:{{range .DefaultHTTPVersions}}
+- "{{.}}"{{end}}{{else}}
 # default-http-versions:
 # - "HTTP/2"
-# - "HTTP/1.1"
+# - "HTTP/1.1"{{end}}
 #
-# The following shows the default proxy timeout settings.
+# The following shows the default proxy timeout settings.{{with .Timeouts}}
+timeouts:{{if .RequestTimeout}}
+  request-timeout: {{.RequestTimeout}}{{else}}
+#   request-timeout: infinity{{end}}{{if .ConnectionIdleTimeout}}
+  connection-idle-timeout: {{.ConnectionIdleTimeout}}{{else}}
+#   connection-idle-timeout: 60s{{end}}{{if .StreamIdleTimeout}}
+  stream-idle-timeout: {{.StreamIdleTimeout}}{{else}}
+#   stream-idle-timeout: 5m{{end}}{{if .MaxConnectionDuration}}
+  max-connection-duration: {{.MaxConnectionDuration}}{{else}}
+#   max-connection-duration: infinity{{end}}
+#   delayed-close-timeout: 1s{{if .ConnectionShutdownGracePeriod}}
+  connection-shutdown-grace-period: {{.ConnectionShutdownGracePeriod}}{{else}}
+#   connection-shutdown-grace-period: 5s{{end}}{{else}}
 # timeouts:
 #   request-timeout: infinity
 #   connection-idle-timeout: 60s
 #   stream-idle-timeout: 5m
 #   max-connection-duration: infinity
 #   delayed-close-timeout: 1s
-#   connection-shutdown-grace-period: 5s
+#   connection-shutdown-grace-period: 5s{{end}}
 #
-# Envoy cluster settings.
-# cluster:
+# Envoy cluster settings.{{if .ClusterPerConnectionBufferLimitBytes }}
+cluster:{{else}}
+# cluster:{{end}}
 #   configure the cluster dns lookup family
 #   valid options are: auto (default), v4, v6
 #   dns-lookup-family: auto
+#   Configure the soft limit on the size of each upstream connection's
+#   read and write buffers.{{if .ClusterPerConnectionBufferLimitBytes }}
+  per-connection-buffer-limit-bytes: {{.ClusterPerConnectionBufferLimitBytes}}{{else}}
+#   per-connection-buffer-limit-bytes: 1048576{{end}}
+#
+# Envoy listener settings.{{if or .ListenerPerConnectionBufferLimitBytes .ListenerHTTP2MaxConcurrentStreams }}
+listener:{{else}}
+# listener:{{end}}
+#   Configure the soft limit on the size of each downstream connection's
+#   read and write buffers.{{if .ListenerPerConnectionBufferLimitBytes }}
+  per-connection-buffer-limit-bytes: {{.ListenerPerConnectionBufferLimitBytes}}{{else}}
+#   per-connection-buffer-limit-bytes: 1048576{{end}}{{if .ListenerHTTP2MaxConcurrentStreams }}
+#   Configure the maximum number of concurrent streams of each downstream
+#   HTTP/2 connection.
+  http2-max-concurrent-streams: {{.ListenerHTTP2MaxConcurrentStreams}}{{end}}
+#
+# Envoy response compression settings.{{if .CompressionAlgorithm }}
+compression:
+#   valid options are: gzip (default), brotli, zstd, disabled
+  algorithm: {{.CompressionAlgorithm}}{{else}}
+# compression:
+#   valid options are: gzip (default), brotli, zstd, disabled
+#   algorithm: gzip{{end}}
 #
-# Envoy network settings.
+# Envoy network settings.{{if .NumTrustedHops }}
+network:
+#   Configure the number of additional ingress proxy hops from the
+#   right side of the x-forwarded-for HTTP header to trust.
+  num-trusted-hops: {{.NumTrustedHops}}{{else}}
 # network:
 #   Configure the number of additional ingress proxy hops from the
 #   right side of the x-forwarded-for HTTP header to trust.
-#   num-trusted-hops: 0
+#   num-trusted-hops: 0{{end}}
+#
+# Configure how Envoy handles the Server header of HTTP responses.
+# valid options are: overwrite (default), append_if_absent, pass_through{{if .ServerHeaderTransformation }}
+serverHeaderTransformation: {{.ServerHeaderTransformation}}{{else}}
+# serverHeaderTransformation: overwrite{{end}}{{with .RateLimitService }}
+#
+# Global rate limit service settings.
+rateLimitService:
+  extensionService: {{.ExtensionService}}{{if .Domain }}
+  domain: {{printf "%q" .Domain}}{{end}}
+  failOpen: {{.FailOpen}}
+  enableXRateLimitHeaders: {{.EnableXRateLimitHeaders}}{{end}}{{with .HeadersPolicy }}
+#
+# Global headers policy settings.
+policy:{{with .Request }}
+  request-headers:{{if .Set }}
+    set:{{range $k, $v := .Set}}
+      {{printf "%q" $k}}: {{printf "%q" $v}}{{end}}{{end}}{{if .Remove }}
+    remove:{{range .Remove}}
+    - {{printf "%q" .}}{{end}}{{end}}{{end}}{{with .Response }}
+  response-headers:{{if .Set }}
+    set:{{range $k, $v := .Set}}
+      {{printf "%q" $k}}: {{printf "%q" $v}}{{end}}{{end}}{{if .Remove }}
+    remove:{{range .Remove}}
+    - {{printf "%q" .}}{{end}}{{end}}{{end}}
+  applyToIngress: {{.ApplyToIngress}}{{end}}
+#
+# Contour metrics listener settings.{{if .MetricsCertificatePath }}
+metrics:
+  contour:
+    address: 0.0.0.0
+    port: {{.MetricsPort}}
+    server-certificate-path: {{.MetricsCertificatePath}}
+    server-key-path: {{.MetricsKeyPath}}{{if .MetricsCACertificatePath }}
+    ca-certificate-path: {{.MetricsCACertificatePath}}{{end}}{{else}}
+# metrics:
+#   contour:
+#     address: 0.0.0.0
+#     port: 8000
+#     server-certificate-path: /path/to/server-cert.pem
+#     server-key-path: /path/to/server-private-key.pem
+#     ca-certificate-path: /path/to/root-ca-for-client-validation.pem{{end}}
 `))
 
 // configMapParams contains everything needed to manage a Contour ConfigMap.
@@ -170,6 +267,108 @@ type contourConfig struct {
 	// EnableExternalNameService sets whether ExternalName Services are
 	// allowed.
 	EnableExternalNameService bool
+
+	// TLSMinimumProtocolVersion is the minimum TLS version Envoy
+	// negotiates with clients.
+	TLSMinimumProtocolVersion string
+
+	// TLSCipherSuites are the cipher suites Envoy negotiates with clients
+	// over TLS 1.2.
+	TLSCipherSuites []string
+
+	// EnvoyClientCertificateName is the name of the Secret holding the
+	// client certificate Envoy presents to upstream services.
+	EnvoyClientCertificateName string
+
+	// EnvoyClientCertificateNamespace is the namespace of the Secret
+	// holding the client certificate Envoy presents to upstream services.
+	EnvoyClientCertificateNamespace string
+
+	// AccessLogFormat is the format of Envoy access logs.
+	AccessLogFormat string
+
+	// AccessLogJSONFields are the fields of Envoy access logs when using
+	// the JSON format.
+	AccessLogJSONFields []string
+
+	// AccessLogLevel is the verbosity of Envoy access logs.
+	AccessLogLevel string
+
+	// DisablePermitInsecure sets whether the permitInsecure field of
+	// HTTPProxy routes is ignored.
+	DisablePermitInsecure bool
+
+	// CompressionAlgorithm is the compression algorithm Envoy applies
+	// to HTTP responses.
+	CompressionAlgorithm string
+
+	// ListenerPerConnectionBufferLimitBytes is the buffer limit of
+	// downstream connections.
+	ListenerPerConnectionBufferLimitBytes int32
+
+	// ListenerHTTP2MaxConcurrentStreams is the maximum number of
+	// concurrent streams of downstream HTTP/2 connections.
+	ListenerHTTP2MaxConcurrentStreams int32
+
+	// ClusterPerConnectionBufferLimitBytes is the buffer limit of
+	// upstream connections.
+	ClusterPerConnectionBufferLimitBytes int32
+
+	// NumTrustedHops is the number of additional ingress proxy hops from
+	// the right side of the x-forwarded-for header to trust.
+	NumTrustedHops int32
+
+	// ServerHeaderTransformation defines how Envoy handles the Server
+	// header of HTTP responses.
+	ServerHeaderTransformation string
+
+	// DefaultHTTPVersions are the HTTP versions Envoy offers to clients.
+	DefaultHTTPVersions []operatorv1alpha1.HTTPVersionType
+
+	// Timeouts are the timeouts Envoy applies to client connections and
+	// requests. Unset timeouts are left commented out.
+	Timeouts *operatorv1alpha1.EnvoyTimeouts
+
+	// RateLimitService configures the global rate limit service used by
+	// Envoy. Global rate limiting is disabled when nil.
+	RateLimitService *rateLimitServiceConfig
+
+	// HeadersPolicy is the global policy applied to request and response
+	// headers.
+	HeadersPolicy *operatorv1alpha1.GlobalHeadersPolicy
+
+	// MetricsPort is the port of Contour's metrics listener.
+	MetricsPort int32
+
+	// MetricsCertificatePath is the path of the serving certificate of
+	// Contour's metrics listener. Metrics are served over TLS when set.
+	MetricsCertificatePath string
+
+	// MetricsKeyPath is the path of the serving key of Contour's metrics
+	// listener.
+	MetricsKeyPath string
+
+	// MetricsCACertificatePath is the path of the CA certificate used to
+	// validate the client certificates of scrapers.
+	MetricsCACertificatePath string
+}
+
+// rateLimitServiceConfig contains the global rate limit service parameters.
+type rateLimitServiceConfig struct {
+	// ExtensionService is the namespace/name of the ExtensionService of
+	// the rate limit service.
+	ExtensionService string
+
+	// Domain is the rate limit domain passed to the rate limit service.
+	Domain string
+
+	// FailOpen sets whether requests are allowed when the rate limit
+	// service is unavailable.
+	FailOpen bool
+
+	// EnableXRateLimitHeaders sets whether Envoy adds X-RateLimit headers
+	// to responses.
+	EnableXRateLimitHeaders bool
 }
 
 // configForContour returns a configMapParams with default fields set for contour.
@@ -186,9 +385,85 @@ func configForContour(contour *operatorv1alpha1.Contour) *configMapParams {
 	if contour.Spec.EnableExternalNameService != nil {
 		cfg.Contour.EnableExternalNameService = *contour.Spec.EnableExternalNameService
 	}
+	if contour.Spec.DisablePermitInsecure != nil {
+		cfg.Contour.DisablePermitInsecure = *contour.Spec.DisablePermitInsecure
+	}
+	if envoy := contour.Spec.Envoy; envoy != nil {
+		if envoy.TLS != nil {
+			cfg.Contour.TLSMinimumProtocolVersion = string(envoy.TLS.MinimumProtocolVersion)
+			cfg.Contour.TLSCipherSuites = envoy.TLS.CipherSuites
+			if cert := envoy.TLS.ClientCertificate; cert != nil {
+				cfg.Contour.EnvoyClientCertificateName = cert.Name
+				cfg.Contour.EnvoyClientCertificateNamespace = cert.Namespace
+				if cert.Namespace == "" {
+					cfg.Contour.EnvoyClientCertificateNamespace = contour.Spec.Namespace.Name
+				}
+			}
+		}
+		if envoy.AccessLog != nil {
+			cfg.Contour.AccessLogFormat = string(envoy.AccessLog.Format)
+			cfg.Contour.AccessLogJSONFields = envoy.AccessLog.JSONFields
+			cfg.Contour.AccessLogLevel = string(envoy.AccessLog.Level)
+		}
+		if envoy.Compression != nil {
+			cfg.Contour.CompressionAlgorithm = string(envoy.Compression.Algorithm)
+		}
+		if envoy.Listener != nil && envoy.Listener.PerConnectionBufferLimitBytes != nil {
+			cfg.Contour.ListenerPerConnectionBufferLimitBytes = *envoy.Listener.PerConnectionBufferLimitBytes
+		}
+		if envoy.Listener != nil && envoy.Listener.HTTP2MaxConcurrentStreams != nil {
+			cfg.Contour.ListenerHTTP2MaxConcurrentStreams = *envoy.Listener.HTTP2MaxConcurrentStreams
+		}
+		if envoy.Cluster != nil && envoy.Cluster.PerConnectionBufferLimitBytes != nil {
+			cfg.Contour.ClusterPerConnectionBufferLimitBytes = *envoy.Cluster.PerConnectionBufferLimitBytes
+		}
+		if envoy.Network != nil && envoy.Network.NumTrustedHops != nil {
+			cfg.Contour.NumTrustedHops = *envoy.Network.NumTrustedHops
+		}
+		cfg.Contour.ServerHeaderTransformation = string(envoy.ServerHeaderTransformation)
+		cfg.Contour.Timeouts = envoy.Timeouts
+		cfg.Contour.DefaultHTTPVersions = envoy.DefaultHTTPVersions
+		cfg.Contour.HeadersPolicy = envoy.HeadersPolicy
+		if rls := envoy.RateLimitService; rls != nil {
+			ns := rls.ExtensionService.Namespace
+			if ns == "" {
+				ns = contour.Spec.Namespace.Name
+			}
+			cfg.Contour.RateLimitService = &rateLimitServiceConfig{
+				ExtensionService:        ns + "/" + rls.ExtensionService.Name,
+				Domain:                  rls.Domain,
+				FailOpen:                rls.FailOpen,
+				EnableXRateLimitHeaders: rls.EnableXRateLimitHeaders,
+			}
+		}
+	}
+	if tls := contour.ContourMetricsTLS(); tls != nil {
+		dir := objcfg.ContourCertsDir
+		if tls.SecretName != "" {
+			dir = objcfg.ContourMetricsCertsDir
+		}
+		cfg.Contour.MetricsPort = objcfg.ContourMetricsPort
+		cfg.Contour.MetricsCertificatePath = filepath.Join(dir, "tls.crt")
+		cfg.Contour.MetricsKeyPath = filepath.Join(dir, "tls.key")
+		if tls.ClientCertificateValidation {
+			cfg.Contour.MetricsCACertificatePath = filepath.Join(dir, "ca.crt")
+		}
+	}
 	return cfg
 }
 
+// ConfigHash returns a hash of the Contour configuration rendered for the
+// given contour. Contour only reads its configuration at startup, so the hash
+// is set on Contour pods to roll them when the configuration changes.
+func ConfigHash(contour *operatorv1alpha1.Contour) (string, error) {
+	cm, err := desired(configForContour(contour))
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256([]byte(cm.Data["contour.yaml"]))
+	return hex.EncodeToString(sum[:]), nil
+}
+
 // EnsureConfigMap ensures that a ConfigMap exists for the given contour.
 func EnsureConfigMap(ctx context.Context, cli client.Client, contour *operatorv1alpha1.Contour) error {
 	cfg := configForContour(contour)
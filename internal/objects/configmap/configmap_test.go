@@ -124,12 +124,39 @@ accesslog-format: envoy
 #   configure the cluster dns lookup family
 #   valid options are: auto (default), v4, v6
 #   dns-lookup-family: auto
+#   Configure the soft limit on the size of each upstream connection's
+#   read and write buffers.
+#   per-connection-buffer-limit-bytes: 1048576
+#
+# Envoy listener settings.
+# listener:
+#   Configure the soft limit on the size of each downstream connection's
+#   read and write buffers.
+#   per-connection-buffer-limit-bytes: 1048576
+#
+# Envoy response compression settings.
+# compression:
+#   valid options are: gzip (default), brotli, zstd, disabled
+#   algorithm: gzip
 #
 # Envoy network settings.
 # network:
 #   Configure the number of additional ingress proxy hops from the
 #   right side of the x-forwarded-for HTTP header to trust.
 #   num-trusted-hops: 0
+#
+# Configure how Envoy handles the Server header of HTTP responses.
+# valid options are: overwrite (default), append_if_absent, pass_through
+# serverHeaderTransformation: overwrite
+#
+# Contour metrics listener settings.
+# metrics:
+#   contour:
+#     address: 0.0.0.0
+#     port: 8000
+#     server-certificate-path: /path/to/server-cert.pem
+#     server-key-path: /path/to/server-private-key.pem
+#     ca-certificate-path: /path/to/root-ca-for-client-validation.pem
 `
 
 	c := &operatorv1alpha1.Contour{
@@ -252,12 +279,39 @@ accesslog-format: envoy
 #   configure the cluster dns lookup family
 #   valid options are: auto (default), v4, v6
 #   dns-lookup-family: auto
+#   Configure the soft limit on the size of each upstream connection's
+#   read and write buffers.
+#   per-connection-buffer-limit-bytes: 1048576
+#
+# Envoy listener settings.
+# listener:
+#   Configure the soft limit on the size of each downstream connection's
+#   read and write buffers.
+#   per-connection-buffer-limit-bytes: 1048576
+#
+# Envoy response compression settings.
+# compression:
+#   valid options are: gzip (default), brotli, zstd, disabled
+#   algorithm: gzip
 #
 # Envoy network settings.
 # network:
 #   Configure the number of additional ingress proxy hops from the
 #   right side of the x-forwarded-for HTTP header to trust.
 #   num-trusted-hops: 0
+#
+# Configure how Envoy handles the Server header of HTTP responses.
+# valid options are: overwrite (default), append_if_absent, pass_through
+# serverHeaderTransformation: overwrite
+#
+# Contour metrics listener settings.
+# metrics:
+#   contour:
+#     address: 0.0.0.0
+#     port: 8000
+#     server-certificate-path: /path/to/server-cert.pem
+#     server-key-path: /path/to/server-private-key.pem
+#     ca-certificate-path: /path/to/root-ca-for-client-validation.pem
 `
 	c := &operatorv1alpha1.Contour{
 		ObjectMeta: v1.ObjectMeta{
@@ -277,3 +331,542 @@ accesslog-format: envoy
 	require.Contains(t, cm.Data, "contour.yaml")
 	assert.Equal(t, expected, cm.Data["contour.yaml"])
 }
+
+func TestDesiredConfigmapWithEnvoySettings(t *testing.T) {
+	expected := `#
+# server:
+#   determine which XDS Server implementation to utilize in Contour.
+#   xds-server-type: contour
+#
+# Specify the Gateway API configuration.
+# gateway:
+#   controllerName: projectcontour.io/projectcontour/contour
+#
+# should contour expect to be running inside a k8s cluster
+# incluster: true
+#
+# path to kubeconfig (if not running inside a k8s cluster)
+# kubeconfig: /path/to/.kube/config
+#
+# Disable RFC-compliant behavior to strip "Content-Length" header if
+# "Tranfer-Encoding: chunked" is also set.
+# disableAllowChunkedLength: false
+# Disable HTTPProxy permitInsecure field
+disablePermitInsecure: false
+tls:
+# minimum TLS version that Contour will negotiate
+# minimum-protocol-version: "1.2"
+# TLS ciphers to be supported by Envoy TLS listeners when negotiating
+# TLS 1.2.
+# cipher-suites:
+# - '[ECDHE-ECDSA-AES128-GCM-SHA256|ECDHE-ECDSA-CHACHA20-POLY1305]'
+# - '[ECDHE-RSA-AES128-GCM-SHA256|ECDHE-RSA-CHACHA20-POLY1305]'
+# - 'ECDHE-ECDSA-AES256-GCM-SHA384'
+# - 'ECDHE-RSA-AES256-GCM-SHA384'
+# Defines the Kubernetes name/namespace matching a secret to use
+# as the fallback certificate when requests which don't match the
+# SNI defined for a vhost.
+  fallback-certificate:
+#   name: fallback-secret-name
+#   namespace: projectcontour
+  envoy-client-certificate:
+#   name: envoy-client-cert-secret-name
+#   namespace: projectcontour
+# The following config shows the defaults for the leader election.
+# leaderelection:
+#   configmap-name: leader-elect
+#   configmap-namespace: projectcontour
+####
+# ExternalName Services are disabled by default due to CVE-2021-XXXXX
+# You can re-enable them by setting this setting to "true".
+# This is not recommended without understanding the security implications.
+# Please see the advisory at https://github.com/projectcontour/contour/security/advisories/GHSA-5ph6-qq5x-7jwc for the details.
+# enableExternalNameService: false
+##
+### Logging options
+# Default setting
+accesslog-format: envoy
+# To enable JSON logging in Envoy
+# accesslog-format: json
+# The default fields that will be logged are specified below.
+# To customize this list, just add or remove entries.
+# The canonical list is available at
+# https://godoc.org/github.com/projectcontour/contour/internal/envoy#JSONFields
+# json-fields:
+#   - "@timestamp"
+#   - "authority"
+#   - "bytes_received"
+#   - "bytes_sent"
+#   - "downstream_local_address"
+#   - "downstream_remote_address"
+#   - "duration"
+#   - "method"
+#   - "path"
+#   - "protocol"
+#   - "request_id"
+#   - "requested_server_name"
+#   - "response_code"
+#   - "response_flags"
+#   - "uber_trace_id"
+#   - "upstream_cluster"
+#   - "upstream_host"
+#   - "upstream_local_address"
+#   - "upstream_service_time"
+#   - "user_agent"
+#   - "x_forwarded_for"
+#
+# default-http-versions:
+# - "HTTP/2"
+# - "HTTP/1.1"
+#
+# The following shows the default proxy timeout settings.
+# timeouts:
+#   request-timeout: infinity
+#   connection-idle-timeout: 60s
+#   stream-idle-timeout: 5m
+#   max-connection-duration: infinity
+#   delayed-close-timeout: 1s
+#   connection-shutdown-grace-period: 5s
+#
+# Envoy cluster settings.
+cluster:
+#   configure the cluster dns lookup family
+#   valid options are: auto (default), v4, v6
+#   dns-lookup-family: auto
+#   Configure the soft limit on the size of each upstream connection's
+#   read and write buffers.
+  per-connection-buffer-limit-bytes: 65536
+#
+# Envoy listener settings.
+listener:
+#   Configure the soft limit on the size of each downstream connection's
+#   read and write buffers.
+  per-connection-buffer-limit-bytes: 32768
+#
+# Envoy response compression settings.
+compression:
+#   valid options are: gzip (default), brotli, zstd, disabled
+  algorithm: brotli
+#
+# Envoy network settings.
+network:
+#   Configure the number of additional ingress proxy hops from the
+#   right side of the x-forwarded-for HTTP header to trust.
+  num-trusted-hops: 2
+#
+# Configure how Envoy handles the Server header of HTTP responses.
+# valid options are: overwrite (default), append_if_absent, pass_through
+serverHeaderTransformation: pass_through
+#
+# Contour metrics listener settings.
+# metrics:
+#   contour:
+#     address: 0.0.0.0
+#     port: 8000
+#     server-certificate-path: /path/to/server-cert.pem
+#     server-key-path: /path/to/server-private-key.pem
+#     ca-certificate-path: /path/to/root-ca-for-client-validation.pem
+`
+	c := &operatorv1alpha1.Contour{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "test",
+			Namespace: "test-ns",
+		},
+		Spec: operatorv1alpha1.ContourSpec{
+			Namespace: operatorv1alpha1.NamespaceSpec{
+				Name: "some-ns",
+			},
+			Envoy: &operatorv1alpha1.EnvoySettings{
+				Compression: &operatorv1alpha1.EnvoyCompression{
+					Algorithm: operatorv1alpha1.BrotliCompression,
+				},
+				Listener: &operatorv1alpha1.EnvoyListenerSettings{
+					PerConnectionBufferLimitBytes: pointer.Int32(32768),
+				},
+				Cluster: &operatorv1alpha1.EnvoyClusterSettings{
+					PerConnectionBufferLimitBytes: pointer.Int32(65536),
+				},
+				Network: &operatorv1alpha1.EnvoyNetworkSettings{
+					NumTrustedHops: pointer.Int32(2),
+				},
+				ServerHeaderTransformation: operatorv1alpha1.PassThroughServerHeader,
+			},
+		},
+	}
+	cm, err := desired(configForContour(c))
+	require.NoError(t, err)
+	require.Contains(t, cm.Data, "contour.yaml")
+	assert.Equal(t, expected, cm.Data["contour.yaml"])
+}
+
+func TestDesiredConfigmapWithMetricsTLS(t *testing.T) {
+	c := &operatorv1alpha1.Contour{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "test",
+			Namespace: "test-ns",
+		},
+		Spec: operatorv1alpha1.ContourSpec{
+			Namespace: operatorv1alpha1.NamespaceSpec{
+				Name: "some-ns",
+			},
+			Contour: &operatorv1alpha1.ContourSettings{
+				MetricsTLS: &operatorv1alpha1.MetricsTLS{},
+			},
+		},
+	}
+	cm, err := desired(configForContour(c))
+	require.NoError(t, err)
+	assert.Contains(t, cm.Data["contour.yaml"], `
+metrics:
+  contour:
+    address: 0.0.0.0
+    port: 8000
+    server-certificate-path: /certs/tls.crt
+    server-key-path: /certs/tls.key
+`)
+	assert.NotContains(t, cm.Data["contour.yaml"], "\n    ca-certificate-path")
+
+	c.Spec.Contour.MetricsTLS = &operatorv1alpha1.MetricsTLS{
+		SecretName:                  "metrics-tls",
+		ClientCertificateValidation: true,
+	}
+	cm, err = desired(configForContour(c))
+	require.NoError(t, err)
+	assert.Contains(t, cm.Data["contour.yaml"], `
+    server-certificate-path: /metrics-certs/tls.crt
+    server-key-path: /metrics-certs/tls.key
+    ca-certificate-path: /metrics-certs/ca.crt
+`)
+}
+
+func TestDesiredConfigmapWithTimeouts(t *testing.T) {
+	c := &operatorv1alpha1.Contour{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "test",
+			Namespace: "test-ns",
+		},
+		Spec: operatorv1alpha1.ContourSpec{
+			Namespace: operatorv1alpha1.NamespaceSpec{
+				Name: "some-ns",
+			},
+			Envoy: &operatorv1alpha1.EnvoySettings{
+				Timeouts: &operatorv1alpha1.EnvoyTimeouts{
+					RequestTimeout:        "30s",
+					StreamIdleTimeout:     "1m",
+					MaxConnectionDuration: "infinity",
+				},
+			},
+		},
+	}
+	cm, err := desired(configForContour(c))
+	require.NoError(t, err)
+	assert.Contains(t, cm.Data["contour.yaml"], `
+# The following shows the default proxy timeout settings.
+timeouts:
+  request-timeout: 30s
+#   connection-idle-timeout: 60s
+  stream-idle-timeout: 1m
+  max-connection-duration: infinity
+#   delayed-close-timeout: 1s
+#   connection-shutdown-grace-period: 5s
+#
+`)
+}
+
+func TestConfigHash(t *testing.T) {
+	c := &operatorv1alpha1.Contour{
+		Spec: operatorv1alpha1.ContourSpec{
+			Namespace: operatorv1alpha1.NamespaceSpec{
+				Name: "some-ns",
+			},
+		},
+	}
+	hash, err := ConfigHash(c)
+	require.NoError(t, err)
+
+	c.Spec.Envoy = &operatorv1alpha1.EnvoySettings{
+		Timeouts: &operatorv1alpha1.EnvoyTimeouts{RequestTimeout: "30s"},
+	}
+	changed, err := ConfigHash(c)
+	require.NoError(t, err)
+	assert.NotEqual(t, hash, changed)
+
+	unchanged, err := ConfigHash(c)
+	require.NoError(t, err)
+	assert.Equal(t, changed, unchanged)
+}
+
+func TestDesiredConfigmapWithAccessLog(t *testing.T) {
+	c := &operatorv1alpha1.Contour{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "test",
+			Namespace: "test-ns",
+		},
+		Spec: operatorv1alpha1.ContourSpec{
+			Namespace: operatorv1alpha1.NamespaceSpec{
+				Name: "some-ns",
+			},
+			Envoy: &operatorv1alpha1.EnvoySettings{
+				AccessLog: &operatorv1alpha1.EnvoyAccessLog{
+					Format:     operatorv1alpha1.JSONAccessLogFormat,
+					JSONFields: []string{"@timestamp", "response_code", "trace_id=%REQ(X-TRACE-ID)%"},
+					Level:      operatorv1alpha1.ErrorAccessLogLevel,
+				},
+			},
+		},
+	}
+	cm, err := desired(configForContour(c))
+	require.NoError(t, err)
+	assert.Contains(t, cm.Data["contour.yaml"], `
+# Default setting
+accesslog-format: json
+accesslog-level: error
+`)
+	assert.Contains(t, cm.Data["contour.yaml"], `
+json-fields:
+  - "@timestamp"
+  - "response_code"
+  - "trace_id=%REQ(X-TRACE-ID)%"
+#
+`)
+	assert.NotContains(t, cm.Data["contour.yaml"], "# json-fields:")
+}
+
+func TestDesiredConfigmapWithTLS(t *testing.T) {
+	c := &operatorv1alpha1.Contour{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "test",
+			Namespace: "test-ns",
+		},
+		Spec: operatorv1alpha1.ContourSpec{
+			Namespace: operatorv1alpha1.NamespaceSpec{
+				Name: "some-ns",
+			},
+			Envoy: &operatorv1alpha1.EnvoySettings{
+				TLS: &operatorv1alpha1.EnvoyTLS{
+					MinimumProtocolVersion: operatorv1alpha1.TLSProtocolVersion1_3,
+					CipherSuites: []string{
+						"ECDHE-ECDSA-AES256-GCM-SHA384",
+						"ECDHE-RSA-AES256-GCM-SHA384",
+					},
+				},
+			},
+		},
+	}
+	cm, err := desired(configForContour(c))
+	require.NoError(t, err)
+	assert.Contains(t, cm.Data["contour.yaml"], `
+tls:
+# minimum TLS version that Contour will negotiate
+  minimum-protocol-version: "1.3"
+# TLS ciphers to be supported by Envoy TLS listeners when negotiating
+# TLS 1.2.
+  cipher-suites:
+  - 'ECDHE-ECDSA-AES256-GCM-SHA384'
+  - 'ECDHE-RSA-AES256-GCM-SHA384'
+# Defines the Kubernetes name/namespace matching a secret to use
+`)
+}
+
+func TestDesiredConfigmapWithHTTP2MaxConcurrentStreams(t *testing.T) {
+	c := &operatorv1alpha1.Contour{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "test",
+			Namespace: "test-ns",
+		},
+		Spec: operatorv1alpha1.ContourSpec{
+			Namespace: operatorv1alpha1.NamespaceSpec{
+				Name: "some-ns",
+			},
+			Envoy: &operatorv1alpha1.EnvoySettings{
+				Listener: &operatorv1alpha1.EnvoyListenerSettings{
+					HTTP2MaxConcurrentStreams: pointer.Int32(500),
+				},
+			},
+		},
+	}
+	cm, err := desired(configForContour(c))
+	require.NoError(t, err)
+	assert.Contains(t, cm.Data["contour.yaml"], `
+# Envoy listener settings.
+listener:
+#   Configure the soft limit on the size of each downstream connection's
+#   read and write buffers.
+#   per-connection-buffer-limit-bytes: 1048576
+#   Configure the maximum number of concurrent streams of each downstream
+#   HTTP/2 connection.
+  http2-max-concurrent-streams: 500
+#
+`)
+}
+
+func TestDesiredConfigmapWithEnvoyClientCertificate(t *testing.T) {
+	c := &operatorv1alpha1.Contour{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "test",
+			Namespace: "test-ns",
+		},
+		Spec: operatorv1alpha1.ContourSpec{
+			Namespace: operatorv1alpha1.NamespaceSpec{
+				Name: "some-ns",
+			},
+			Envoy: &operatorv1alpha1.EnvoySettings{
+				TLS: &operatorv1alpha1.EnvoyTLS{
+					ClientCertificate: &operatorv1alpha1.SecretReference{Name: "envoy-client"},
+				},
+			},
+		},
+	}
+	cm, err := desired(configForContour(c))
+	require.NoError(t, err)
+	assert.Contains(t, cm.Data["contour.yaml"], `
+  envoy-client-certificate:
+    name: envoy-client
+    namespace: some-ns
+`)
+
+	c.Spec.Envoy.TLS.ClientCertificate.Namespace = "certs"
+	cm, err = desired(configForContour(c))
+	require.NoError(t, err)
+	assert.Contains(t, cm.Data["contour.yaml"], `
+  envoy-client-certificate:
+    name: envoy-client
+    namespace: certs
+`)
+}
+
+func TestDesiredConfigmapWithDefaultHTTPVersions(t *testing.T) {
+	c := &operatorv1alpha1.Contour{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "test",
+			Namespace: "test-ns",
+		},
+		Spec: operatorv1alpha1.ContourSpec{
+			Namespace: operatorv1alpha1.NamespaceSpec{
+				Name: "some-ns",
+			},
+			Envoy: &operatorv1alpha1.EnvoySettings{
+				DefaultHTTPVersions: []operatorv1alpha1.HTTPVersionType{operatorv1alpha1.HTTPVersion1},
+			},
+		},
+	}
+	cm, err := desired(configForContour(c))
+	require.NoError(t, err)
+	assert.Contains(t, cm.Data["contour.yaml"], `
+#
+default-http-versions:
+- "HTTP/1.1"
+#
+`)
+	assert.NotContains(t, cm.Data["contour.yaml"], "# default-http-versions:")
+}
+
+func TestDesiredConfigmapWithDisablePermitInsecure(t *testing.T) {
+	c := &operatorv1alpha1.Contour{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "test",
+			Namespace: "test-ns",
+		},
+		Spec: operatorv1alpha1.ContourSpec{
+			Namespace: operatorv1alpha1.NamespaceSpec{
+				Name: "some-ns",
+			},
+			DisablePermitInsecure: pointer.Bool(true),
+		},
+	}
+	cm, err := desired(configForContour(c))
+	require.NoError(t, err)
+	assert.Contains(t, cm.Data["contour.yaml"], `
+# Disable HTTPProxy permitInsecure field
+disablePermitInsecure: true
+`)
+}
+
+func TestDesiredConfigmapWithRateLimitService(t *testing.T) {
+	c := &operatorv1alpha1.Contour{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "test",
+			Namespace: "test-ns",
+		},
+		Spec: operatorv1alpha1.ContourSpec{
+			Namespace: operatorv1alpha1.NamespaceSpec{
+				Name: "some-ns",
+			},
+			Envoy: &operatorv1alpha1.EnvoySettings{
+				RateLimitService: &operatorv1alpha1.RateLimitServiceSettings{
+					ExtensionService: operatorv1alpha1.ExtensionServiceReference{Name: "ratelimit"},
+					FailOpen:         true,
+				},
+			},
+		},
+	}
+	cm, err := desired(configForContour(c))
+	require.NoError(t, err)
+	assert.Contains(t, cm.Data["contour.yaml"], `
+# serverHeaderTransformation: overwrite
+#
+# Global rate limit service settings.
+rateLimitService:
+  extensionService: some-ns/ratelimit
+  failOpen: true
+  enableXRateLimitHeaders: false
+#
+`)
+
+	c.Spec.Envoy.RateLimitService.ExtensionService.Namespace = "ratelimit"
+	c.Spec.Envoy.RateLimitService.Domain = "ingress"
+	cm, err = desired(configForContour(c))
+	require.NoError(t, err)
+	assert.Contains(t, cm.Data["contour.yaml"], `
+rateLimitService:
+  extensionService: ratelimit/ratelimit
+  domain: "ingress"
+`)
+}
+
+func TestDesiredConfigmapWithHeadersPolicy(t *testing.T) {
+	c := &operatorv1alpha1.Contour{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "test",
+			Namespace: "test-ns",
+		},
+		Spec: operatorv1alpha1.ContourSpec{
+			Namespace: operatorv1alpha1.NamespaceSpec{
+				Name: "some-ns",
+			},
+			Envoy: &operatorv1alpha1.EnvoySettings{
+				HeadersPolicy: &operatorv1alpha1.GlobalHeadersPolicy{
+					Request: &operatorv1alpha1.HeadersPolicy{
+						Set: map[string]string{
+							"X-Request-Start": "t=%START_TIME(%s.%3f)%",
+							"X-Envoy-Gateway": "contour",
+						},
+						Remove: []string{"X-Forwarded-Client-Cert"},
+					},
+					Response: &operatorv1alpha1.HeadersPolicy{
+						Remove: []string{"Server"},
+					},
+				},
+			},
+		},
+	}
+	cm, err := desired(configForContour(c))
+	require.NoError(t, err)
+	assert.Contains(t, cm.Data["contour.yaml"], `
+# serverHeaderTransformation: overwrite
+#
+# Global headers policy settings.
+policy:
+  request-headers:
+    set:
+      "X-Envoy-Gateway": "contour"
+      "X-Request-Start": "t=%START_TIME(%s.%3f)%"
+    remove:
+    - "X-Forwarded-Client-Cert"
+  response-headers:
+    remove:
+    - "Server"
+  applyToIngress: false
+#
+`)
+}
@@ -34,6 +34,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
 const (
@@ -45,6 +46,9 @@ const (
 	EnvoyContainerName = "envoy"
 	// ShutdownContainerName is the name of the Shutdown Manager container.
 	ShutdownContainerName = "shutdown-manager"
+	// DefaultTerminationGracePeriodSeconds is the default termination grace
+	// period of Envoy pods.
+	DefaultTerminationGracePeriodSeconds = int64(300)
 	// envoyInitContainerName is the name of the Envoy init container.
 	envoyInitContainerName = "envoy-initconfig"
 	// envoyNsEnvVar is the name of the contour namespace environment variable.
@@ -116,6 +120,107 @@ func EnsureDaemonSetDeleted(ctx context.Context, cli client.Client, contour *ope
 // contourImage as the shutdown-manager/envoy-initconfig container images and
 // envoyImage as Envoy's container image.
 func DesiredDaemonSet(contour *operatorv1alpha1.Contour, contourImage, envoyImage string) *appsv1.DaemonSet {
+	return &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: contour.Spec.Namespace.Name,
+			Name:      envoyDaemonSetName,
+			Labels:    envoyLabels(contour),
+		},
+		Spec: appsv1.DaemonSetSpec{
+			RevisionHistoryLimit: pointer.Int32Ptr(int32(10)),
+			// Ensure the deamonset adopts only its own pods.
+			Selector:       EnvoyDaemonSetPodSelector(),
+			UpdateStrategy: envoyUpdateStrategy(contour),
+			Template:       desiredEnvoyPodTemplate(contour, contourImage, envoyImage),
+		},
+	}
+}
+
+// ReservedVolumeNames returns the names of the volumes the operator manages
+// in Envoy pods.
+func ReservedVolumeNames() []string {
+	return []string{envoyCertsVolName, envoyCfgVolName, envoyAdminVolName}
+}
+
+// ReservedContainerNames returns the names of the containers the operator
+// manages in Envoy pods.
+func ReservedContainerNames() []string {
+	return []string{EnvoyContainerName, ShutdownContainerName, envoyInitContainerName}
+}
+
+// ReservedEnvVarNames returns the names of the environment variables the
+// operator manages in the Envoy container.
+func ReservedEnvVarNames() []string {
+	return []string{envoyNsEnvVar, envoyPodEnvVar}
+}
+
+// envoyConcurrency returns the number of Envoy worker threads for contour,
+// or 0 if Envoy should run one worker thread per core.
+func envoyConcurrency(contour *operatorv1alpha1.Contour) int32 {
+	if contour.Spec.Envoy == nil || contour.Spec.Envoy.Concurrency == nil {
+		return 0
+	}
+	concurrency := contour.Spec.Envoy.Concurrency
+	if concurrency.Workers != nil {
+		return *concurrency.Workers
+	}
+	if concurrency.FromCPULimit && contour.Spec.Resources != nil {
+		if limit, ok := contour.Spec.Resources.Envoy.Limits[corev1.ResourceCPU]; ok {
+			// Round partial cores up so Envoy always gets a worker.
+			return int32((limit.MilliValue() + 999) / 1000)
+		}
+	}
+	return 0
+}
+
+// envoyShutdownCommand returns the preStop command of the shutdown-manager
+// container for contour, which drains Envoy before it's stopped.
+func envoyShutdownCommand(contour *operatorv1alpha1.Contour) []string {
+	cmd := []string{"/bin/contour", "envoy", "shutdown"}
+	if contour.Spec.Envoy == nil || contour.Spec.Envoy.Shutdown == nil {
+		return cmd
+	}
+	shutdown := contour.Spec.Envoy.Shutdown
+	if shutdown.DrainDelay != nil {
+		cmd = append(cmd, fmt.Sprintf("--drain-delay=%s", shutdown.DrainDelay.Duration))
+	}
+	if shutdown.CheckDelay != nil {
+		cmd = append(cmd, fmt.Sprintf("--check-delay=%s", shutdown.CheckDelay.Duration))
+	}
+	if shutdown.CheckInterval != nil {
+		cmd = append(cmd, fmt.Sprintf("--check-interval=%s", shutdown.CheckInterval.Duration))
+	}
+	if shutdown.MinOpenConnections != nil {
+		cmd = append(cmd, fmt.Sprintf("--min-open-connections=%d", *shutdown.MinOpenConnections))
+	}
+	return cmd
+}
+
+// envoyUpdateStrategy returns the update strategy of the Envoy DaemonSet for
+// contour, with the fields defaulted by the API server set explicitly.
+func envoyUpdateStrategy(contour *operatorv1alpha1.Contour) appsv1.DaemonSetUpdateStrategy {
+	strategy := appsv1.DaemonSetUpdateStrategy{}
+	if contour.Spec.Envoy != nil && contour.Spec.Envoy.UpdateStrategy != nil {
+		contour.Spec.Envoy.UpdateStrategy.DeepCopyInto(&strategy)
+	}
+	if strategy.Type == appsv1.OnDeleteDaemonSetStrategyType {
+		return strategy
+	}
+	strategy.Type = appsv1.RollingUpdateDaemonSetStrategyType
+	if strategy.RollingUpdate == nil {
+		strategy.RollingUpdate = &appsv1.RollingUpdateDaemonSet{}
+	}
+	if strategy.RollingUpdate.MaxUnavailable == nil {
+		strategy.RollingUpdate.MaxUnavailable = opintstr.PointerTo(intstr.FromString("10%"))
+	}
+	if strategy.RollingUpdate.MaxSurge == nil {
+		strategy.RollingUpdate.MaxSurge = opintstr.PointerTo(intstr.FromInt(0))
+	}
+	return strategy
+}
+
+// envoyLabels returns the labels of the Envoy workload for the provided contour.
+func envoyLabels(contour *operatorv1alpha1.Contour) map[string]string {
 	labels := map[string]string{
 		"app.kubernetes.io/name":       "contour",
 		"app.kubernetes.io/instance":   contour.Name,
@@ -126,7 +231,14 @@ func DesiredDaemonSet(contour *operatorv1alpha1.Contour, contourImage, envoyImag
 	for k, v := range objcontour.OwnerLabels(contour) {
 		labels[k] = v
 	}
+	return labels
+}
 
+// desiredEnvoyPodTemplate returns the desired Envoy pod template for the provided
+// contour using contourImage as the shutdown-manager/envoy-initconfig container
+// images and envoyImage as Envoy's container image.
+func desiredEnvoyPodTemplate(contour *operatorv1alpha1.Contour, contourImage, envoyImage string) corev1.PodTemplateSpec {
+	networkType := contour.EnvoyNetworkType()
 	var ports []corev1.ContainerPort
 	for _, port := range contour.Spec.NetworkPublishing.Envoy.ContainerPorts {
 		p := corev1.ContainerPort{
@@ -134,14 +246,41 @@ func DesiredDaemonSet(contour *operatorv1alpha1.Contour, contourImage, envoyImag
 			ContainerPort: port.PortNumber,
 			Protocol:      corev1.ProtocolTCP,
 		}
+		if networkType == operatorv1alpha1.HostPortsEnvoyNetwork {
+			switch port.Name {
+			case "http":
+				p.HostPort = objcfg.EnvoyHTTPHostPort
+			case "https":
+				p.HostPort = objcfg.EnvoyHTTPSHostPort
+			}
+		}
+		ports = append(ports, p)
+	}
+	for _, port := range contour.Spec.NetworkPublishing.Envoy.ExtraPorts {
+		p := corev1.ContainerPort{
+			Name:          port.Name,
+			ContainerPort: port.ContainerPortNumber,
+			Protocol:      corev1.ProtocolTCP,
+		}
+		if networkType == operatorv1alpha1.HostPortsEnvoyNetwork {
+			p.HostPort = port.PortNumber
+		}
 		ports = append(ports, p)
 	}
+	// The API server defaults host ports to container ports for pods using
+	// the host network, so set them explicitly to avoid spurious updates.
+	if networkType == operatorv1alpha1.HostNetworkEnvoyNetwork {
+		for i := range ports {
+			ports[i].HostPort = ports[i].ContainerPort
+		}
+	}
 
 	containers := []corev1.Container{
 		{
 			Name:            ShutdownContainerName,
 			Image:           contourImage,
-			ImagePullPolicy: corev1.PullIfNotPresent,
+			ImagePullPolicy: objutil.PullPolicyFor(contour.ContourImageOverride(), corev1.PullIfNotPresent),
+			SecurityContext: objutil.NewRestrictedContainerSecurity(),
 			Command: []string{
 				"/bin/contour",
 			},
@@ -166,7 +305,7 @@ func DesiredDaemonSet(contour *operatorv1alpha1.Contour, contourImage, envoyImag
 			Lifecycle: &corev1.Lifecycle{
 				PreStop: &corev1.LifecycleHandler{
 					Exec: &corev1.ExecAction{
-						Command: []string{"/bin/contour", "envoy", "shutdown"},
+						Command: envoyShutdownCommand(contour),
 					},
 				},
 			},
@@ -182,7 +321,8 @@ func DesiredDaemonSet(contour *operatorv1alpha1.Contour, contourImage, envoyImag
 		{
 			Name:            EnvoyContainerName,
 			Image:           envoyImage,
-			ImagePullPolicy: corev1.PullIfNotPresent,
+			ImagePullPolicy: objutil.PullPolicyFor(contour.EnvoyImageOverride(), corev1.PullIfNotPresent),
+			SecurityContext: objutil.NewRestrictedContainerSecurity(),
 			Command: []string{
 				"envoy",
 			},
@@ -257,20 +397,48 @@ func DesiredDaemonSet(contour *operatorv1alpha1.Contour, contourImage, envoyImag
 			TerminationMessagePath:   "/dev/termination-log",
 		},
 	}
+	if resources := contour.Spec.Resources; resources != nil {
+		for i := range containers {
+			switch containers[i].Name {
+			case ShutdownContainerName:
+				containers[i].Resources = resources.ShutdownManager
+			case EnvoyContainerName:
+				containers[i].Resources = resources.Envoy
+			}
+		}
+	}
+	if workers := envoyConcurrency(contour); workers > 0 {
+		for i := range containers {
+			if containers[i].Name == EnvoyContainerName {
+				containers[i].Args = append(containers[i].Args, fmt.Sprintf("--concurrency %d", workers))
+			}
+		}
+	}
 
+	xdsAddress, xdsPort := "contour", objcfg.XDSPort
+	if contour.Spec.Envoy != nil && contour.Spec.Envoy.Bootstrap != nil {
+		bootstrap := contour.Spec.Envoy.Bootstrap
+		if bootstrap.XDSAddress != "" {
+			xdsAddress = bootstrap.XDSAddress
+		}
+		if bootstrap.XDSPort != nil {
+			xdsPort = *bootstrap.XDSPort
+		}
+	}
 	initContainers := []corev1.Container{
 		{
 			Name:            envoyInitContainerName,
 			Image:           contourImage,
-			ImagePullPolicy: corev1.PullIfNotPresent,
+			ImagePullPolicy: objutil.PullPolicyFor(contour.ContourImageOverride(), corev1.PullIfNotPresent),
+			SecurityContext: objutil.NewRestrictedContainerSecurity(),
 			Command: []string{
 				"contour",
 			},
 			Args: []string{
 				"bootstrap",
 				filepath.Join("/", envoyCfgVolMntDir, envoyCfgFileName),
-				"--xds-address=contour",
-				fmt.Sprintf("--xds-port=%d", objcfg.XDSPort),
+				fmt.Sprintf("--xds-address=%s", xdsAddress),
+				fmt.Sprintf("--xds-port=%d", xdsPort),
 				fmt.Sprintf("--xds-resource-version=%s", xdsResourceVersion),
 				fmt.Sprintf("--resources-dir=%s", filepath.Join("/", envoyCfgVolMntDir, "resources")),
 				fmt.Sprintf("--envoy-cafile=%s", filepath.Join("/", envoyCertsVolMntDir, "ca.crt")),
@@ -304,82 +472,118 @@ func DesiredDaemonSet(contour *operatorv1alpha1.Contour, contourImage, envoyImag
 			TerminationMessagePath:   "/dev/termination-log",
 		},
 	}
+	if resources := contour.Spec.Resources; resources != nil {
+		initContainers[0].Resources = resources.EnvoyInitConfig
+	}
 
-	ds := &appsv1.DaemonSet{
+	template := corev1.PodTemplateSpec{
 		ObjectMeta: metav1.ObjectMeta{
-			Namespace: contour.Spec.Namespace.Name,
-			Name:      envoyDaemonSetName,
-			Labels:    labels,
+			// TODO [danehans]: Remove the prometheus annotations when Contour is updated to
+			// show how the Prometheus Operator is used to scrape Contour/Envoy metrics.
+			Annotations: map[string]string{
+				"prometheus.io/scrape": "true",
+				"prometheus.io/port":   "8002",
+				"prometheus.io/path":   "/stats/prometheus",
+			},
+			Labels: EnvoyDaemonSetPodSelector().MatchLabels,
 		},
-		Spec: appsv1.DaemonSetSpec{
-			RevisionHistoryLimit: pointer.Int32Ptr(int32(10)),
-			// Ensure the deamonset adopts only its own pods.
-			Selector: EnvoyDaemonSetPodSelector(),
-			UpdateStrategy: appsv1.DaemonSetUpdateStrategy{
-				Type: appsv1.RollingUpdateDaemonSetStrategyType,
-				RollingUpdate: &appsv1.RollingUpdateDaemonSet{
-					MaxUnavailable: opintstr.PointerTo(intstr.FromString("10%")),
+		Spec: corev1.PodSpec{
+			Containers:       containers,
+			InitContainers:   initContainers,
+			ImagePullSecrets: contour.ImagePullSecrets(),
+			Volumes: []corev1.Volume{
+				{
+					Name: envoyCertsVolName,
+					VolumeSource: corev1.VolumeSource{
+						Secret: &corev1.SecretVolumeSource{
+							DefaultMode: pointer.Int32Ptr(int32(420)),
+							SecretName:  envoyCertsSecretName,
+						},
+					},
 				},
-			},
-			Template: corev1.PodTemplateSpec{
-				ObjectMeta: metav1.ObjectMeta{
-					// TODO [danehans]: Remove the prometheus annotations when Contour is updated to
-					// show how the Prometheus Operator is used to scrape Contour/Envoy metrics.
-					Annotations: map[string]string{
-						"prometheus.io/scrape": "true",
-						"prometheus.io/port":   "8002",
-						"prometheus.io/path":   "/stats/prometheus",
+				{
+					Name: envoyCfgVolName,
+					VolumeSource: corev1.VolumeSource{
+						EmptyDir: &corev1.EmptyDirVolumeSource{},
 					},
-					Labels: EnvoyDaemonSetPodSelector().MatchLabels,
 				},
-				Spec: corev1.PodSpec{
-					Containers:     containers,
-					InitContainers: initContainers,
-					Volumes: []corev1.Volume{
-						{
-							Name: envoyCertsVolName,
-							VolumeSource: corev1.VolumeSource{
-								Secret: &corev1.SecretVolumeSource{
-									DefaultMode: pointer.Int32Ptr(int32(420)),
-									SecretName:  envoyCertsSecretName,
-								},
-							},
-						},
-						{
-							Name: envoyCfgVolName,
-							VolumeSource: corev1.VolumeSource{
-								EmptyDir: &corev1.EmptyDirVolumeSource{},
-							},
-						},
-						{
-							Name: envoyAdminVolName,
-							VolumeSource: corev1.VolumeSource{
-								EmptyDir: &corev1.EmptyDirVolumeSource{},
-							},
-						},
+				{
+					Name: envoyAdminVolName,
+					VolumeSource: corev1.VolumeSource{
+						EmptyDir: &corev1.EmptyDirVolumeSource{},
 					},
-					ServiceAccountName:            objutil.EnvoyRbacName,
-					DeprecatedServiceAccount:      EnvoyContainerName,
-					AutomountServiceAccountToken:  pointer.BoolPtr(false),
-					TerminationGracePeriodSeconds: pointer.Int64Ptr(int64(300)),
-					SecurityContext:               objutil.NewUnprivilegedPodSecurity(),
-					DNSPolicy:                     corev1.DNSClusterFirst,
-					RestartPolicy:                 corev1.RestartPolicyAlways,
-					SchedulerName:                 "default-scheduler",
 				},
 			},
+			ServiceAccountName:            objutil.EnvoyRbacName,
+			DeprecatedServiceAccount:      EnvoyContainerName,
+			AutomountServiceAccountToken:  pointer.BoolPtr(false),
+			TerminationGracePeriodSeconds: pointer.Int64Ptr(DefaultTerminationGracePeriodSeconds),
+			SecurityContext:               objutil.NewUnprivilegedPodSecurity(),
+			DNSPolicy:                     corev1.DNSClusterFirst,
+			RestartPolicy:                 corev1.RestartPolicyAlways,
+			SchedulerName:                 "default-scheduler",
 		},
 	}
 
+	if networkType == operatorv1alpha1.HostNetworkEnvoyNetwork {
+		template.Spec.HostNetwork = true
+		// Keep resolving cluster DNS names, e.g. of the xDS service.
+		template.Spec.DNSPolicy = corev1.DNSClusterFirstWithHostNet
+	}
+
+	if settings := contour.Spec.Envoy; settings != nil {
+		objutil.MergePodMetadata(&template.ObjectMeta, settings.PodLabels, settings.PodAnnotations)
+		template.Spec.PriorityClassName = settings.PriorityClassName
+		if settings.PodSecurityContext != nil {
+			template.Spec.SecurityContext = settings.PodSecurityContext.DeepCopy()
+		}
+		if settings.SecurityContext != nil {
+			for i := range template.Spec.Containers {
+				template.Spec.Containers[i].SecurityContext = settings.SecurityContext.DeepCopy()
+			}
+			for i := range template.Spec.InitContainers {
+				template.Spec.InitContainers[i].SecurityContext = settings.SecurityContext.DeepCopy()
+			}
+		}
+		if settings.DNSPolicy != "" {
+			template.Spec.DNSPolicy = settings.DNSPolicy
+		}
+		template.Spec.DNSConfig = settings.DNSConfig.DeepCopy()
+		if settings.TerminationGracePeriodSeconds != nil {
+			template.Spec.TerminationGracePeriodSeconds = pointer.Int64Ptr(*settings.TerminationGracePeriodSeconds)
+		}
+		template.Spec.Volumes = append(template.Spec.Volumes, objutil.DefaultVolumes(settings.ExtraVolumes)...)
+		for i := range template.Spec.Containers {
+			if template.Spec.Containers[i].Name == EnvoyContainerName {
+				template.Spec.Containers[i].VolumeMounts = append(template.Spec.Containers[i].VolumeMounts,
+					settings.ExtraVolumeMounts...)
+				template.Spec.Containers[i].Env = append(template.Spec.Containers[i].Env,
+					objutil.DefaultEnv(settings.ExtraEnv)...)
+				objutil.ApplyProbeSettings(template.Spec.Containers[i].ReadinessProbe, settings.ReadinessProbe)
+			}
+		}
+		template.Spec.Containers = append(template.Spec.Containers, objutil.DefaultContainers(settings.ExtraContainers)...)
+		template.Spec.InitContainers = append(template.Spec.InitContainers, objutil.DefaultContainers(settings.ExtraInitContainers)...)
+	}
+
 	if contour.EnvoyNodeSelectorExists() {
-		ds.Spec.Template.Spec.NodeSelector = contour.Spec.NodePlacement.Envoy.NodeSelector
+		template.Spec.NodeSelector = contour.Spec.NodePlacement.Envoy.NodeSelector
 	}
 
 	if contour.EnvoyTolerationsExist() {
-		ds.Spec.Template.Spec.Tolerations = contour.Spec.NodePlacement.Envoy.Tolerations
+		template.Spec.Tolerations = contour.Spec.NodePlacement.Envoy.Tolerations
+	}
+
+	if contour.EnvoyAffinityExists() {
+		template.Spec.Affinity = contour.Spec.NodePlacement.Envoy.Affinity.DeepCopy()
+	}
+
+	if placement := contour.Spec.NodePlacement; placement != nil && placement.Envoy != nil {
+		template.Spec.TopologySpreadConstraints = objutil.TopologySpreadConstraints(
+			placement.Envoy.TopologySpreadConstraints, EnvoyDaemonSetPodSelector())
 	}
 
-	return ds
+	return template
 }
 
 // CurrentDaemonSet returns the current DaemonSet resource for the provided contour.
@@ -409,6 +613,8 @@ func updateDaemonSetIfNeeded(ctx context.Context, cli client.Client, contour *op
 	if labels.Exist(current, objcontour.OwnerLabels(contour)) {
 		ds, updated := equality.DaemonsetConfigChanged(current, desired)
 		if updated {
+			log.FromContext(ctx).Info("updating daemonset", "namespace", ds.Namespace, "name", ds.Name,
+				"diff", equality.Diff(current.Spec, ds.Spec))
 			if err := cli.Update(ctx, ds); err != nil {
 				return fmt.Errorf("failed to update daemonset %s/%s: %w", ds.Namespace, ds.Name, err)
 			}
@@ -16,6 +16,7 @@ package daemonset
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	operatorv1alpha1 "github.com/projectcontour/contour-operator/api/v1alpha1"
 	objcontour "github.com/projectcontour/contour-operator/internal/objects/contour"
@@ -23,6 +24,10 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/pointer"
 )
 
 func checkDaemonSetHasEnvVar(t *testing.T, ds *appsv1.DaemonSet, container, name string) {
@@ -145,6 +150,9 @@ func checkDaemonSecurityContext(t *testing.T, ds *appsv1.DaemonSet) {
 		RunAsUser:    &user,
 		RunAsGroup:   &group,
 		RunAsNonRoot: &nonRoot,
+		SeccompProfile: &corev1.SeccompProfile{
+			Type: corev1.SeccompProfileTypeRuntimeDefault,
+		},
 	}
 	if apiequality.Semantic.DeepEqual(ds.Spec.Template.Spec.SecurityContext, expected) {
 		return
@@ -181,6 +189,57 @@ func TestDesiredDaemonSet(t *testing.T) {
 	checkDaemonSetHasNodeSelector(t, ds, nil)
 	checkDaemonSetHasTolerations(t, ds, nil)
 	checkDaemonSecurityContext(t, ds)
+
+	cntr.Spec.NetworkPublishing.Envoy.ExtraPorts = []operatorv1alpha1.ExtraPort{
+		{Name: "tcp-proxy", PortNumber: 9000, ContainerPortNumber: 9000},
+	}
+	ds = DesiredDaemonSet(cntr, testContourImage, testEnvoyImage)
+	checkContainerHasPort(t, ds, 9000)
+
+	cntr.Spec.Envoy = &operatorv1alpha1.EnvoySettings{
+		PodLabels:         map[string]string{"app": "other", "cost-center": "ingress"},
+		PodAnnotations:    map[string]string{"sidecar.istio.io/inject": "false"},
+		PriorityClassName: "system-cluster-critical",
+	}
+	ds = DesiredDaemonSet(cntr, testContourImage, testEnvoyImage)
+	if ds.Spec.Template.Spec.PriorityClassName != "system-cluster-critical" {
+		t.Errorf("daemonset has unexpected priority class %q", ds.Spec.Template.Spec.PriorityClassName)
+	}
+	if ds.Spec.Template.Labels["app"] != "envoy" || ds.Spec.Template.Labels["cost-center"] != "ingress" {
+		t.Errorf("daemonset has unexpected pod labels %v", ds.Spec.Template.Labels)
+	}
+	if ds.Spec.Template.Annotations["sidecar.istio.io/inject"] != "false" {
+		t.Errorf("daemonset has unexpected pod annotations %v", ds.Spec.Template.Annotations)
+	}
+	cntr.Spec.Envoy = nil
+
+	cntr.Spec.ImagePullSecretRefs = []operatorv1alpha1.ImagePullSecretRef{{Name: "registry-creds", Mirror: true}}
+	ds = DesiredDaemonSet(cntr, testContourImage, testEnvoyImage)
+	expectedSecrets := []corev1.LocalObjectReference{{Name: "registry-creds"}}
+	if !apiequality.Semantic.DeepEqual(ds.Spec.Template.Spec.ImagePullSecrets, expectedSecrets) {
+		t.Errorf("daemonset has unexpected image pull secrets %v", ds.Spec.Template.Spec.ImagePullSecrets)
+	}
+
+	envoyResources := corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m")},
+		Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("512Mi")},
+	}
+	shutdownResources := corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("10m")},
+	}
+	cntr.Spec.Resources = &operatorv1alpha1.ContainerResources{
+		Envoy:           envoyResources,
+		ShutdownManager: shutdownResources,
+	}
+	ds = DesiredDaemonSet(cntr, testContourImage, testEnvoyImage)
+	container = checkDaemonSetHasContainer(t, ds, EnvoyContainerName, true)
+	if !apiequality.Semantic.DeepEqual(container.Resources, envoyResources) {
+		t.Errorf("envoy container has unexpected resources %v", container.Resources)
+	}
+	container = checkDaemonSetHasContainer(t, ds, ShutdownContainerName, true)
+	if !apiequality.Semantic.DeepEqual(container.Resources, shutdownResources) {
+		t.Errorf("shutdown-manager container has unexpected resources %v", container.Resources)
+	}
 }
 
 func TestNodePlacementDaemonSet(t *testing.T) {
@@ -214,4 +273,437 @@ func TestNodePlacementDaemonSet(t *testing.T) {
 	ds := DesiredDaemonSet(cntr, testContourImage, testEnvoyImage)
 	checkDaemonSetHasNodeSelector(t, ds, selectors)
 	checkDaemonSetHasTolerations(t, ds, tolerations)
+
+	nodeAffinity := &corev1.NodeAffinity{
+		RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+			NodeSelectorTerms: []corev1.NodeSelectorTerm{
+				{
+					MatchExpressions: []corev1.NodeSelectorRequirement{
+						{
+							Key:      "node-role.kubernetes.io/edge",
+							Operator: corev1.NodeSelectorOpExists,
+						},
+					},
+				},
+			},
+		},
+	}
+	cntr.Spec.NodePlacement.Envoy.Affinity = &corev1.Affinity{NodeAffinity: nodeAffinity}
+	cntr.Spec.NodePlacement.Envoy.TopologySpreadConstraints = []corev1.TopologySpreadConstraint{
+		{
+			MaxSkew:           1,
+			TopologyKey:       "topology.kubernetes.io/zone",
+			WhenUnsatisfiable: corev1.ScheduleAnyway,
+		},
+	}
+	ds = DesiredDaemonSet(cntr, testContourImage, testEnvoyImage)
+	if !apiequality.Semantic.DeepEqual(ds.Spec.Template.Spec.Affinity, cntr.Spec.NodePlacement.Envoy.Affinity) {
+		t.Errorf("daemonset has affinity %v; expected %v", ds.Spec.Template.Spec.Affinity, cntr.Spec.NodePlacement.Envoy.Affinity)
+	}
+	constraints := ds.Spec.Template.Spec.TopologySpreadConstraints
+	if len(constraints) != 1 || !apiequality.Semantic.DeepEqual(constraints[0].LabelSelector, EnvoyDaemonSetPodSelector()) {
+		t.Errorf("daemonset has topology spread constraints %v; expected one selecting envoy pods", constraints)
+	}
+
+	// The Envoy Deployment keeps its default anti-affinity next to the
+	// user's node affinity.
+	cntr.Spec.Envoy = &operatorv1alpha1.EnvoySettings{WorkloadType: operatorv1alpha1.DeploymentEnvoyWorkload}
+	deploy := DesiredEnvoyDeployment(cntr, testContourImage, testEnvoyImage)
+	affinity := deploy.Spec.Template.Spec.Affinity
+	if !apiequality.Semantic.DeepEqual(affinity.NodeAffinity, nodeAffinity) || affinity.PodAntiAffinity == nil {
+		t.Errorf("deployment has affinity %v; expected node affinity and default pod anti-affinity", affinity)
+	}
+	if cntr.Spec.NodePlacement.Envoy.Affinity.PodAntiAffinity != nil {
+		t.Errorf("desired deployment modified the envoy affinity of the contour")
+	}
+}
+
+func TestDesiredEnvoyDeployment(t *testing.T) {
+	name := "deploy-test"
+	cfg := objcontour.Config{
+		Name:        name,
+		Namespace:   fmt.Sprintf("%s-ns", name),
+		SpecNs:      "projectcontour",
+		RemoveNs:    false,
+		NetworkType: operatorv1alpha1.LoadBalancerServicePublishingType,
+	}
+	cntr := objcontour.New(cfg)
+	cntr.Spec.Envoy = &operatorv1alpha1.EnvoySettings{
+		WorkloadType: operatorv1alpha1.DeploymentEnvoyWorkload,
+	}
+	testContourImage := "ghcr.io/projectcontour/contour:test"
+	testEnvoyImage := "docker.io/envoyproxy/envoy:test"
+	deploy := DesiredEnvoyDeployment(cntr, testContourImage, testEnvoyImage)
+	ds := DesiredDaemonSet(cntr, testContourImage, testEnvoyImage)
+
+	if *deploy.Spec.Replicas != defaultEnvoyReplicas {
+		t.Errorf("deployment has %d replicas; expected %d", *deploy.Spec.Replicas, defaultEnvoyReplicas)
+	}
+	if !apiequality.Semantic.DeepEqual(deploy.Spec.Selector, ds.Spec.Selector) {
+		t.Errorf("deployment has selector %v; expected %v", deploy.Spec.Selector, ds.Spec.Selector)
+	}
+	if !apiequality.Semantic.DeepEqual(deploy.Labels, ds.Labels) {
+		t.Errorf("deployment has labels %v; expected %v", deploy.Labels, ds.Labels)
+	}
+	if deploy.Spec.Template.Spec.Affinity == nil || deploy.Spec.Template.Spec.Affinity.PodAntiAffinity == nil {
+		t.Errorf("deployment is missing pod anti-affinity")
+	}
+	cntr.Spec.Envoy.Replicas = pointer.Int32Ptr(5)
+	if replicas := *DesiredEnvoyDeployment(cntr, testContourImage, testEnvoyImage).Spec.Replicas; replicas != 5 {
+		t.Errorf("deployment has %d replicas; expected 5", replicas)
+	}
+
+	// Apart from anti-affinity, Envoy pods are the same for both workload types.
+	deploy.Spec.Template.Spec.Affinity = nil
+	if !apiequality.Semantic.DeepEqual(deploy.Spec.Template, ds.Spec.Template) {
+		t.Errorf("deployment pod template differs from daemonset pod template")
+	}
+}
+
+func TestEnvoyNetworkType(t *testing.T) {
+	name := "network-test"
+	cfg := objcontour.Config{
+		Name:        name,
+		Namespace:   fmt.Sprintf("%s-ns", name),
+		SpecNs:      "projectcontour",
+		RemoveNs:    false,
+		NetworkType: operatorv1alpha1.LoadBalancerServicePublishingType,
+	}
+	cntr := objcontour.New(cfg)
+	cntr.Spec.NetworkPublishing.Envoy.ExtraPorts = []operatorv1alpha1.ExtraPort{
+		{Name: "tcp-proxy", PortNumber: 9000, ContainerPortNumber: 9090},
+	}
+	testContourImage := "ghcr.io/projectcontour/contour:test"
+	testEnvoyImage := "docker.io/envoyproxy/envoy:test"
+
+	hostPorts := func(ds *appsv1.DaemonSet) map[string]int32 {
+		ports := map[string]int32{}
+		for _, c := range ds.Spec.Template.Spec.Containers {
+			if c.Name == EnvoyContainerName {
+				for _, p := range c.Ports {
+					ports[p.Name] = p.HostPort
+				}
+			}
+		}
+		return ports
+	}
+
+	ds := DesiredDaemonSet(cntr, testContourImage, testEnvoyImage)
+	if ports := hostPorts(ds); ports["http"] != 0 || ports["https"] != 0 || ports["tcp-proxy"] != 0 {
+		t.Errorf("daemonset has unexpected host ports %v", ports)
+	}
+	if ds.Spec.Template.Spec.HostNetwork {
+		t.Errorf("daemonset unexpectedly uses the host network")
+	}
+
+	cntr.Spec.Envoy = &operatorv1alpha1.EnvoySettings{NetworkType: operatorv1alpha1.HostPortsEnvoyNetwork}
+	ds = DesiredDaemonSet(cntr, testContourImage, testEnvoyImage)
+	expected := map[string]int32{"http": 80, "https": 443, "tcp-proxy": 9000}
+	if ports := hostPorts(ds); !apiequality.Semantic.DeepEqual(ports, expected) {
+		t.Errorf("daemonset has host ports %v; expected %v", ports, expected)
+	}
+
+	cntr.Spec.Envoy.NetworkType = operatorv1alpha1.HostNetworkEnvoyNetwork
+	ds = DesiredDaemonSet(cntr, testContourImage, testEnvoyImage)
+	expected = map[string]int32{"http": 8080, "https": 8443, "tcp-proxy": 9090}
+	if ports := hostPorts(ds); !apiequality.Semantic.DeepEqual(ports, expected) {
+		t.Errorf("daemonset has host ports %v; expected %v", ports, expected)
+	}
+	if !ds.Spec.Template.Spec.HostNetwork || ds.Spec.Template.Spec.DNSPolicy != corev1.DNSClusterFirstWithHostNet {
+		t.Errorf("daemonset has host network %t and dns policy %q; expected host network with %q",
+			ds.Spec.Template.Spec.HostNetwork, ds.Spec.Template.Spec.DNSPolicy, corev1.DNSClusterFirstWithHostNet)
+	}
+
+	cntr.Spec.Envoy.WorkloadType = operatorv1alpha1.DeploymentEnvoyWorkload
+	deploy := DesiredEnvoyDeployment(cntr, testContourImage, testEnvoyImage)
+	if surge := deploy.Spec.Strategy.RollingUpdate.MaxSurge; surge.IntValue() != 0 {
+		t.Errorf("deployment has max surge %s; expected 0", surge.String())
+	}
+}
+
+func TestEnvoyUpdateStrategy(t *testing.T) {
+	cntr := &operatorv1alpha1.Contour{}
+
+	strategy := envoyUpdateStrategy(cntr)
+	if strategy.Type != appsv1.RollingUpdateDaemonSetStrategyType ||
+		strategy.RollingUpdate.MaxUnavailable.String() != "10%" || strategy.RollingUpdate.MaxSurge.IntValue() != 0 {
+		t.Errorf("unexpected default update strategy %v", strategy)
+	}
+
+	maxSurge := intstr.FromString("20%")
+	cntr.Spec.Envoy = &operatorv1alpha1.EnvoySettings{
+		UpdateStrategy: &appsv1.DaemonSetUpdateStrategy{
+			RollingUpdate: &appsv1.RollingUpdateDaemonSet{MaxSurge: &maxSurge},
+		},
+	}
+	strategy = envoyUpdateStrategy(cntr)
+	if strategy.Type != appsv1.RollingUpdateDaemonSetStrategyType ||
+		strategy.RollingUpdate.MaxUnavailable.String() != "10%" || strategy.RollingUpdate.MaxSurge.String() != "20%" {
+		t.Errorf("unexpected update strategy %v", strategy)
+	}
+
+	cntr.Spec.Envoy.UpdateStrategy = &appsv1.DaemonSetUpdateStrategy{Type: appsv1.OnDeleteDaemonSetStrategyType}
+	strategy = envoyUpdateStrategy(cntr)
+	if strategy.Type != appsv1.OnDeleteDaemonSetStrategyType || strategy.RollingUpdate != nil {
+		t.Errorf("unexpected update strategy %v", strategy)
+	}
+}
+
+func TestEnvoyExtraVolumes(t *testing.T) {
+	name := "volumes-test"
+	cfg := objcontour.Config{
+		Name:        name,
+		Namespace:   fmt.Sprintf("%s-ns", name),
+		SpecNs:      "projectcontour",
+		RemoveNs:    false,
+		NetworkType: operatorv1alpha1.LoadBalancerServicePublishingType,
+	}
+	cntr := objcontour.New(cfg)
+	cntr.Spec.Envoy = &operatorv1alpha1.EnvoySettings{
+		ExtraVolumes: []corev1.Volume{{
+			Name: "logs",
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			},
+		}},
+		ExtraVolumeMounts: []corev1.VolumeMount{{Name: "logs", MountPath: "/var/log/envoy"}},
+	}
+
+	ds := DesiredDaemonSet(cntr, "ghcr.io/projectcontour/contour:test", "docker.io/envoyproxy/envoy:test")
+	volumes := ds.Spec.Template.Spec.Volumes
+	if vol := volumes[len(volumes)-1]; vol.Name != "logs" || vol.EmptyDir == nil {
+		t.Errorf("daemonset has unexpected extra volume %v", vol)
+	}
+	container := checkDaemonSetHasContainer(t, ds, EnvoyContainerName, true)
+	mounts := container.VolumeMounts
+	if mount := mounts[len(mounts)-1]; mount.Name != "logs" || mount.MountPath != "/var/log/envoy" {
+		t.Errorf("envoy container has unexpected extra volume mount %v", mount)
+	}
+}
+
+func TestEnvoyExtraEnv(t *testing.T) {
+	name := "env-test"
+	cfg := objcontour.Config{
+		Name:        name,
+		Namespace:   fmt.Sprintf("%s-ns", name),
+		SpecNs:      "projectcontour",
+		RemoveNs:    false,
+		NetworkType: operatorv1alpha1.LoadBalancerServicePublishingType,
+	}
+	cntr := objcontour.New(cfg)
+	cntr.Spec.Envoy = &operatorv1alpha1.EnvoySettings{
+		ExtraEnv: []corev1.EnvVar{
+			{
+				Name: "ENVOY_FLAGS",
+				ValueFrom: &corev1.EnvVarSource{
+					ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "envoy-flags"},
+						Key:                  "flags",
+					},
+				},
+			},
+		},
+	}
+
+	ds := DesiredDaemonSet(cntr, "ghcr.io/projectcontour/contour:test", "docker.io/envoyproxy/envoy:test")
+	checkDaemonSetHasEnvVar(t, ds, EnvoyContainerName, envoyPodEnvVar)
+	checkDaemonSetHasEnvVar(t, ds, EnvoyContainerName, "ENVOY_FLAGS")
+}
+
+func TestEnvoyExtraContainers(t *testing.T) {
+	name := "containers-test"
+	cfg := objcontour.Config{
+		Name:        name,
+		Namespace:   fmt.Sprintf("%s-ns", name),
+		SpecNs:      "projectcontour",
+		RemoveNs:    false,
+		NetworkType: operatorv1alpha1.LoadBalancerServicePublishingType,
+	}
+	cntr := objcontour.New(cfg)
+	cntr.Spec.Envoy = &operatorv1alpha1.EnvoySettings{
+		ExtraContainers:     []corev1.Container{{Name: "fluent-bit", Image: "fluent/fluent-bit:2.0"}},
+		ExtraInitContainers: []corev1.Container{{Name: "sysctl", Image: "busybox:1.36"}},
+	}
+
+	ds := DesiredDaemonSet(cntr, "ghcr.io/projectcontour/contour:test", "docker.io/envoyproxy/envoy:test")
+	checkDaemonSetHasContainer(t, ds, EnvoyContainerName, true)
+	container := checkDaemonSetHasContainer(t, ds, "fluent-bit", true)
+	checkContainerHasImage(t, container, "fluent/fluent-bit:2.0")
+	initContainers := ds.Spec.Template.Spec.InitContainers
+	if len(initContainers) != 2 || initContainers[0].Name != envoyInitContainerName || initContainers[1].Name != "sysctl" {
+		t.Errorf("daemonset has unexpected init containers %v", initContainers)
+	}
+}
+
+func TestEnvoyShutdown(t *testing.T) {
+	name := "shutdown-test"
+	cfg := objcontour.Config{
+		Name:        name,
+		Namespace:   fmt.Sprintf("%s-ns", name),
+		SpecNs:      "projectcontour",
+		RemoveNs:    false,
+		NetworkType: operatorv1alpha1.LoadBalancerServicePublishingType,
+	}
+	cntr := objcontour.New(cfg)
+
+	ds := DesiredDaemonSet(cntr, "ghcr.io/projectcontour/contour:test", "docker.io/envoyproxy/envoy:test")
+	container := checkDaemonSetHasContainer(t, ds, ShutdownContainerName, true)
+	expected := []string{"/bin/contour", "envoy", "shutdown"}
+	if !apiequality.Semantic.DeepEqual(container.Lifecycle.PreStop.Exec.Command, expected) {
+		t.Errorf("shutdown-manager has unexpected preStop command %v", container.Lifecycle.PreStop.Exec.Command)
+	}
+	if *ds.Spec.Template.Spec.TerminationGracePeriodSeconds != DefaultTerminationGracePeriodSeconds {
+		t.Errorf("daemonset has unexpected termination grace period %d", *ds.Spec.Template.Spec.TerminationGracePeriodSeconds)
+	}
+
+	cntr.Spec.Envoy = &operatorv1alpha1.EnvoySettings{
+		TerminationGracePeriodSeconds: pointer.Int64Ptr(3600),
+		Shutdown: &operatorv1alpha1.EnvoyShutdownSettings{
+			DrainDelay:         &metav1.Duration{Duration: 30 * time.Second},
+			CheckInterval:      &metav1.Duration{Duration: 10 * time.Second},
+			MinOpenConnections: pointer.Int32Ptr(5),
+		},
+	}
+	ds = DesiredDaemonSet(cntr, "ghcr.io/projectcontour/contour:test", "docker.io/envoyproxy/envoy:test")
+	container = checkDaemonSetHasContainer(t, ds, ShutdownContainerName, true)
+	expected = []string{"/bin/contour", "envoy", "shutdown", "--drain-delay=30s", "--check-interval=10s", "--min-open-connections=5"}
+	if !apiequality.Semantic.DeepEqual(container.Lifecycle.PreStop.Exec.Command, expected) {
+		t.Errorf("shutdown-manager has unexpected preStop command %v", container.Lifecycle.PreStop.Exec.Command)
+	}
+	if *ds.Spec.Template.Spec.TerminationGracePeriodSeconds != 3600 {
+		t.Errorf("daemonset has unexpected termination grace period %d", *ds.Spec.Template.Spec.TerminationGracePeriodSeconds)
+	}
+}
+
+func TestEnvoyConcurrency(t *testing.T) {
+	cntr := &operatorv1alpha1.Contour{}
+	if workers := envoyConcurrency(cntr); workers != 0 {
+		t.Errorf("expected no concurrency, got %d", workers)
+	}
+
+	cntr.Spec.Envoy = &operatorv1alpha1.EnvoySettings{
+		Concurrency: &operatorv1alpha1.EnvoyConcurrency{Workers: pointer.Int32Ptr(4)},
+	}
+	if workers := envoyConcurrency(cntr); workers != 4 {
+		t.Errorf("expected concurrency 4, got %d", workers)
+	}
+
+	cntr.Spec.Envoy.Concurrency = &operatorv1alpha1.EnvoyConcurrency{FromCPULimit: true}
+	cntr.Spec.Resources = &operatorv1alpha1.ContainerResources{
+		Envoy: corev1.ResourceRequirements{
+			Limits: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1500m")},
+		},
+	}
+	if workers := envoyConcurrency(cntr); workers != 2 {
+		t.Errorf("expected concurrency 2, got %d", workers)
+	}
+}
+
+func TestEnvoySecurityContext(t *testing.T) {
+	name := "security-test"
+	cfg := objcontour.Config{
+		Name:        name,
+		Namespace:   fmt.Sprintf("%s-ns", name),
+		SpecNs:      "projectcontour",
+		RemoveNs:    false,
+		NetworkType: operatorv1alpha1.LoadBalancerServicePublishingType,
+	}
+	cntr := objcontour.New(cfg)
+
+	ds := DesiredDaemonSet(cntr, "ghcr.io/projectcontour/contour:test", "docker.io/envoyproxy/envoy:test")
+	for _, name := range []string{EnvoyContainerName, ShutdownContainerName, envoyInitContainerName} {
+		container := checkDaemonSetHasContainer(t, ds, name, true)
+		sc := container.SecurityContext
+		if sc == nil || sc.AllowPrivilegeEscalation == nil || *sc.AllowPrivilegeEscalation ||
+			len(sc.Capabilities.Drop) != 1 || sc.Capabilities.Drop[0] != "ALL" {
+			t.Errorf("container %q has unexpected security context %v", name, sc)
+		}
+	}
+
+	cntr.Spec.Envoy = &operatorv1alpha1.EnvoySettings{
+		PodSecurityContext: &corev1.PodSecurityContext{RunAsUser: pointer.Int64Ptr(1000)},
+		SecurityContext:    &corev1.SecurityContext{ReadOnlyRootFilesystem: pointer.BoolPtr(true)},
+		ExtraContainers:    []corev1.Container{{Name: "fluent-bit", Image: "fluent/fluent-bit:2.0"}},
+	}
+	ds = DesiredDaemonSet(cntr, "ghcr.io/projectcontour/contour:test", "docker.io/envoyproxy/envoy:test")
+	if !apiequality.Semantic.DeepEqual(ds.Spec.Template.Spec.SecurityContext, cntr.Spec.Envoy.PodSecurityContext) {
+		t.Errorf("daemonset has unexpected security context %v", ds.Spec.Template.Spec.SecurityContext)
+	}
+	for _, name := range []string{EnvoyContainerName, ShutdownContainerName, envoyInitContainerName} {
+		container := checkDaemonSetHasContainer(t, ds, name, true)
+		if !apiequality.Semantic.DeepEqual(container.SecurityContext, cntr.Spec.Envoy.SecurityContext) {
+			t.Errorf("container %q has unexpected security context %v", name, container.SecurityContext)
+		}
+	}
+	if container := checkDaemonSetHasContainer(t, ds, "fluent-bit", true); container.SecurityContext != nil {
+		t.Errorf("extra container has unexpected security context %v", container.SecurityContext)
+	}
+}
+
+func TestEnvoyDNS(t *testing.T) {
+	name := "dns-test"
+	cfg := objcontour.Config{
+		Name:        name,
+		Namespace:   fmt.Sprintf("%s-ns", name),
+		SpecNs:      "projectcontour",
+		RemoveNs:    false,
+		NetworkType: operatorv1alpha1.LoadBalancerServicePublishingType,
+	}
+	cntr := objcontour.New(cfg)
+	cntr.Spec.Envoy = &operatorv1alpha1.EnvoySettings{NetworkType: operatorv1alpha1.HostNetworkEnvoyNetwork}
+
+	ds := DesiredDaemonSet(cntr, "ghcr.io/projectcontour/contour:test", "docker.io/envoyproxy/envoy:test")
+	if ds.Spec.Template.Spec.DNSPolicy != corev1.DNSClusterFirstWithHostNet || ds.Spec.Template.Spec.DNSConfig != nil {
+		t.Errorf("daemonset has unexpected dns policy %q and config %v", ds.Spec.Template.Spec.DNSPolicy, ds.Spec.Template.Spec.DNSConfig)
+	}
+
+	cntr.Spec.Envoy.DNSPolicy = corev1.DNSNone
+	cntr.Spec.Envoy.DNSConfig = &corev1.PodDNSConfig{
+		Nameservers: []string{"169.254.20.10"},
+		Searches:    []string{"projectcontour.svc.cluster.local", "corp.example.com"},
+	}
+	ds = DesiredDaemonSet(cntr, "ghcr.io/projectcontour/contour:test", "docker.io/envoyproxy/envoy:test")
+	if ds.Spec.Template.Spec.DNSPolicy != corev1.DNSNone ||
+		!apiequality.Semantic.DeepEqual(ds.Spec.Template.Spec.DNSConfig, cntr.Spec.Envoy.DNSConfig) {
+		t.Errorf("daemonset has unexpected dns policy %q and config %v", ds.Spec.Template.Spec.DNSPolicy, ds.Spec.Template.Spec.DNSConfig)
+	}
+}
+
+func TestEnvoyBootstrap(t *testing.T) {
+	name := "bootstrap-test"
+	cfg := objcontour.Config{
+		Name:        name,
+		Namespace:   fmt.Sprintf("%s-ns", name),
+		SpecNs:      "projectcontour",
+		RemoveNs:    false,
+		NetworkType: operatorv1alpha1.LoadBalancerServicePublishingType,
+	}
+	cntr := objcontour.New(cfg)
+	cntr.Spec.Envoy = &operatorv1alpha1.EnvoySettings{
+		Bootstrap: &operatorv1alpha1.EnvoyBootstrapSettings{
+			XDSAddress: "contour.projectcontour.svc.cluster.local",
+			XDSPort:    pointer.Int32Ptr(18001),
+		},
+	}
+	limits := corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("64Mi")}
+	cntr.Spec.Resources = &operatorv1alpha1.ContainerResources{
+		EnvoyInitConfig: corev1.ResourceRequirements{Limits: limits},
+	}
+
+	ds := DesiredDaemonSet(cntr, "ghcr.io/projectcontour/contour:test", "docker.io/envoyproxy/envoy:test")
+	container := checkDaemonSetHasContainer(t, ds, envoyInitContainerName, true)
+	for _, expected := range []string{"--xds-address=contour.projectcontour.svc.cluster.local", "--xds-port=18001"} {
+		found := false
+		for _, arg := range container.Args {
+			if arg == expected {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("init container is missing arg %q", expected)
+		}
+	}
+	if !apiequality.Semantic.DeepEqual(container.Resources.Limits, limits) {
+		t.Errorf("init container has unexpected resources %v", container.Resources)
+	}
 }
@@ -0,0 +1,195 @@
+// Copyright Project Contour Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package daemonset
+
+import (
+	"context"
+	"fmt"
+
+	operatorv1alpha1 "github.com/projectcontour/contour-operator/api/v1alpha1"
+	"github.com/projectcontour/contour-operator/internal/equality"
+	opintstr "github.com/projectcontour/contour-operator/internal/intstr"
+	objcontour "github.com/projectcontour/contour-operator/internal/objects/contour"
+	"github.com/projectcontour/contour-operator/pkg/labels"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	// EnvoyDeploymentName is the name of Envoy's Deployment resource.
+	EnvoyDeploymentName = envoyDaemonSetName
+	// defaultEnvoyReplicas is the number of Envoy replicas run by the
+	// Deployment workload when unspecified.
+	defaultEnvoyReplicas = int32(2)
+)
+
+// EnsureEnvoyDeployment ensures a Deployment running Envoy exists for the given contour.
+func EnsureEnvoyDeployment(ctx context.Context, cli client.Client, contour *operatorv1alpha1.Contour, contourImage, envoyImage string) error {
+	desired := DesiredEnvoyDeployment(contour, contourImage, envoyImage)
+	current, err := CurrentEnvoyDeployment(ctx, cli, contour)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return createEnvoyDeployment(ctx, cli, desired)
+		}
+		return fmt.Errorf("failed to get deployment %s/%s: %w", desired.Namespace, desired.Name, err)
+	}
+	differ := equality.DeploymentSelectorsDiffer(current, desired)
+	if differ {
+		return EnsureEnvoyDeploymentDeleted(ctx, cli, contour)
+	}
+	// Leave the replica count to the autoscaler when enabled.
+	if contour.EnvoyAutoscalingEnabled() {
+		desired.Spec.Replicas = current.Spec.Replicas
+	}
+	if err := updateEnvoyDeploymentIfNeeded(ctx, cli, contour, current, desired); err != nil {
+		return fmt.Errorf("failed to update envoy deployment for contour %s/%s: %w", contour.Namespace, contour.Name, err)
+	}
+	return nil
+}
+
+// EnsureEnvoyDeploymentDeleted ensures the Deployment running Envoy for the provided
+// contour is deleted if Contour owner labels exist.
+func EnsureEnvoyDeploymentDeleted(ctx context.Context, cli client.Client, contour *operatorv1alpha1.Contour) error {
+	deploy, err := CurrentEnvoyDeployment(ctx, cli, contour)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if labels.Exist(deploy, objcontour.OwnerLabels(contour)) {
+		if err := cli.Delete(ctx, deploy); err != nil {
+			if errors.IsNotFound(err) {
+				return nil
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// DesiredEnvoyDeployment returns the desired Deployment running Envoy for the
+// provided contour using contourImage as the shutdown-manager/envoy-initconfig
+// container images and envoyImage as Envoy's container image.
+func DesiredEnvoyDeployment(contour *operatorv1alpha1.Contour, contourImage, envoyImage string) *appsv1.Deployment {
+	replicas := defaultEnvoyReplicas
+	if contour.Spec.Envoy != nil && contour.Spec.Envoy.Replicas != nil {
+		replicas = *contour.Spec.Envoy.Replicas
+	}
+
+	template := desiredEnvoyPodTemplate(contour, contourImage, envoyImage)
+	// Spread Envoy pods across nodes when possible, unless the user
+	// provides their own pod anti-affinity.
+	if template.Spec.Affinity == nil {
+		template.Spec.Affinity = &corev1.Affinity{}
+	}
+	if template.Spec.Affinity.PodAntiAffinity == nil {
+		template.Spec.Affinity.PodAntiAffinity = &corev1.PodAntiAffinity{
+			PreferredDuringSchedulingIgnoredDuringExecution: []corev1.WeightedPodAffinityTerm{
+				{
+					Weight: int32(100),
+					PodAffinityTerm: corev1.PodAffinityTerm{
+						TopologyKey: "kubernetes.io/hostname",
+						LabelSelector: &metav1.LabelSelector{
+							MatchLabels: EnvoyDaemonSetPodSelector().MatchLabels,
+						},
+					},
+				},
+			},
+		}
+	}
+
+	deploy := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: contour.Spec.Namespace.Name,
+			Name:      EnvoyDeploymentName,
+			Labels:    envoyLabels(contour),
+		},
+		Spec: appsv1.DeploymentSpec{
+			ProgressDeadlineSeconds: pointer.Int32Ptr(int32(600)),
+			Replicas:                pointer.Int32Ptr(replicas),
+			RevisionHistoryLimit:    pointer.Int32Ptr(int32(10)),
+			// Ensure the deployment adopts only its own pods.
+			Selector: EnvoyDaemonSetPodSelector(),
+			// Envoy drains connections on shutdown, so surge new pods before
+			// taking old ones out of rotation.
+			Strategy: appsv1.DeploymentStrategy{
+				Type: appsv1.RollingUpdateDeploymentStrategyType,
+				RollingUpdate: &appsv1.RollingUpdateDeployment{
+					MaxSurge:       opintstr.PointerTo(intstr.FromString("25%")),
+					MaxUnavailable: opintstr.PointerTo(intstr.FromInt(0)),
+				},
+			},
+			Template: template,
+		},
+	}
+
+	// Surge pods only fit on nodes whose ports are free, which stalls the
+	// rollout once every node runs Envoy, so replace pods in place instead.
+	if contour.EnvoyNetworkType() != operatorv1alpha1.ClusterIPEnvoyNetwork {
+		deploy.Spec.Strategy.RollingUpdate = &appsv1.RollingUpdateDeployment{
+			MaxSurge:       opintstr.PointerTo(intstr.FromInt(0)),
+			MaxUnavailable: opintstr.PointerTo(intstr.FromInt(1)),
+		}
+	}
+
+	return deploy
+}
+
+// CurrentEnvoyDeployment returns the current Deployment running Envoy for the
+// provided contour.
+func CurrentEnvoyDeployment(ctx context.Context, cli client.Client, contour *operatorv1alpha1.Contour) (*appsv1.Deployment, error) {
+	deploy := &appsv1.Deployment{}
+	key := types.NamespacedName{
+		Namespace: contour.Spec.Namespace.Name,
+		Name:      EnvoyDeploymentName,
+	}
+	if err := cli.Get(ctx, key, deploy); err != nil {
+		return nil, err
+	}
+	return deploy, nil
+}
+
+// createEnvoyDeployment creates a Deployment resource for the provided deploy.
+func createEnvoyDeployment(ctx context.Context, cli client.Client, deploy *appsv1.Deployment) error {
+	if err := cli.Create(ctx, deploy); err != nil {
+		return fmt.Errorf("failed to create deployment %s/%s: %w", deploy.Namespace, deploy.Name, err)
+	}
+	return nil
+}
+
+// updateEnvoyDeploymentIfNeeded updates a Deployment running Envoy if current
+// does not match desired, using contour to verify the existence of owner labels.
+func updateEnvoyDeploymentIfNeeded(ctx context.Context, cli client.Client, contour *operatorv1alpha1.Contour, current, desired *appsv1.Deployment) error {
+	if labels.Exist(current, objcontour.OwnerLabels(contour)) {
+		deploy, updated := equality.DeploymentConfigChanged(current, desired)
+		if updated {
+			log.FromContext(ctx).Info("updating deployment", "namespace", deploy.Namespace, "name", deploy.Name,
+				"diff", equality.Diff(current.Spec, deploy.Spec))
+			if err := cli.Update(ctx, deploy); err != nil {
+				return fmt.Errorf("failed to update deployment %s/%s: %w", deploy.Namespace, deploy.Name, err)
+			}
+			return nil
+		}
+	}
+	return nil
+}
@@ -17,6 +17,7 @@ import (
 	"context"
 	"fmt"
 	"path/filepath"
+	"strings"
 
 	operatorv1alpha1 "github.com/projectcontour/contour-operator/api/v1alpha1"
 	"github.com/projectcontour/contour-operator/internal/equality"
@@ -35,6 +36,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
 const (
@@ -61,9 +63,18 @@ const (
 	// contourCfgFileName is the name of the contour configuration file.
 	contourCfgFileName = "contour.yaml"
 	// metricsPort is the network port number of Contour's metrics service.
-	metricsPort = 8000
+	metricsPort = objcfg.ContourMetricsPort
 	// debugPort is the network port number of Contour's debug service.
 	debugPort = 6060
+	// healthPort is the network port number of Contour's health service
+	// when metrics are served over TLS.
+	healthPort = objcfg.ContourHealthPort
+	// contourMetricsCertsVolName is the name of the volume holding the
+	// user-provided certificate of Contour's metrics listener.
+	contourMetricsCertsVolName = "metrics-certs"
+	// contourConfigHashAnnotation is the pod annotation holding the hash of
+	// Contour's configuration, used to roll Contour pods when it changes.
+	contourConfigHashAnnotation = "contour.operator.projectcontour.io/config-hash"
 )
 
 // EnsureDeployment ensures a deployment using image exists for the given contour.
@@ -136,10 +147,17 @@ func DesiredDeployment(contour *operatorv1alpha1.Contour, image string) *appsv1.
 	if contour.Spec.IngressClassName != nil {
 		args = append(args, fmt.Sprintf("--ingress-class-name=%s", *contour.Spec.IngressClassName))
 	}
+	if len(contour.Spec.RootNamespaces) > 0 {
+		args = append(args, fmt.Sprintf("--root-namespaces=%s", strings.Join(contour.Spec.RootNamespaces, ",")))
+	}
+	if contour.ProxyProtocolEnabled() {
+		args = append(args, "--use-proxy-protocol")
+	}
 	container := corev1.Container{
 		Name:            contourContainerName,
 		Image:           image,
-		ImagePullPolicy: corev1.PullIfNotPresent,
+		ImagePullPolicy: objutil.PullPolicyFor(contour.ContourImageOverride(), corev1.PullIfNotPresent),
+		SecurityContext: objutil.NewRestrictedContainerSecurity(),
 		Command:         []string{"contour"},
 		Args:            args,
 		Env: []corev1.EnvVar{
@@ -221,6 +239,30 @@ func DesiredDeployment(contour *operatorv1alpha1.Contour, image string) *appsv1.
 			},
 		},
 	}
+	if contour.Spec.Resources != nil {
+		container.Resources = contour.Spec.Resources.Contour
+	}
+	container.Env = append(container.Env, objutil.ProxyEnv(contour)...)
+	metricsTLS := contour.ContourMetricsTLS()
+	if metricsTLS != nil {
+		// The health endpoint would otherwise share the TLS listener
+		// of metrics, which kubelet probes can't authenticate to.
+		container.Args = append(container.Args, fmt.Sprintf("--health-port=%d", healthPort))
+		container.Ports = append(container.Ports, corev1.ContainerPort{
+			Name:          "health",
+			ContainerPort: healthPort,
+			Protocol:      "TCP",
+		})
+		container.LivenessProbe.HTTPGet.Port = intstr.IntOrString{IntVal: healthPort}
+		if metricsTLS.SecretName != "" {
+			container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+				Name:      contourMetricsCertsVolName,
+				MountPath: objcfg.ContourMetricsCertsDir,
+				ReadOnly:  true,
+			})
+		}
+	}
+
 	deploy := &appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace: contour.Spec.Namespace.Name,
@@ -233,13 +275,7 @@ func DesiredDeployment(contour *operatorv1alpha1.Contour, image string) *appsv1.
 			RevisionHistoryLimit:    pointer.Int32Ptr(int32(10)),
 			// Ensure the deployment adopts only its own pods.
 			Selector: ContourDeploymentPodSelector(),
-			Strategy: appsv1.DeploymentStrategy{
-				Type: appsv1.RollingUpdateDeploymentStrategyType,
-				RollingUpdate: &appsv1.RollingUpdateDeployment{
-					MaxSurge:       opintstr.PointerTo(intstr.FromString("50%")),
-					MaxUnavailable: opintstr.PointerTo(intstr.FromString("25%")),
-				},
-			},
+			Strategy: contourStrategy(contour),
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
 					// TODO [danehans]: Remove the prometheus annotations when Contour is updated to
@@ -268,7 +304,8 @@ func DesiredDeployment(contour *operatorv1alpha1.Contour, image string) *appsv1.
 							},
 						},
 					},
-					Containers: []corev1.Container{container},
+					Containers:       []corev1.Container{container},
+					ImagePullSecrets: contour.ImagePullSecrets(),
 					Volumes: []corev1.Volume{
 						{
 							Name: contourCertsVolName,
@@ -311,6 +348,21 @@ func DesiredDeployment(contour *operatorv1alpha1.Contour, image string) *appsv1.
 		},
 	}
 
+	if metricsTLS != nil {
+		deploy.Spec.Template.Annotations["prometheus.io/scheme"] = "https"
+		if metricsTLS.SecretName != "" {
+			deploy.Spec.Template.Spec.Volumes = append(deploy.Spec.Template.Spec.Volumes, corev1.Volume{
+				Name: contourMetricsCertsVolName,
+				VolumeSource: corev1.VolumeSource{
+					Secret: &corev1.SecretVolumeSource{
+						DefaultMode: pointer.Int32Ptr(int32(420)),
+						SecretName:  metricsTLS.SecretName,
+					},
+				},
+			})
+		}
+	}
+
 	if contour.ContourNodeSelectorExists() {
 		deploy.Spec.Template.Spec.NodeSelector = contour.Spec.NodePlacement.Contour.NodeSelector
 	}
@@ -319,6 +371,54 @@ func DesiredDeployment(contour *operatorv1alpha1.Contour, image string) *appsv1.
 		deploy.Spec.Template.Spec.Tolerations = contour.Spec.NodePlacement.Contour.Tolerations
 	}
 
+	if settings := contour.Spec.Contour; settings != nil {
+		objutil.MergePodMetadata(&deploy.Spec.Template.ObjectMeta, settings.PodLabels, settings.PodAnnotations)
+		deploy.Spec.Template.Spec.PriorityClassName = settings.PriorityClassName
+		if settings.PodSecurityContext != nil {
+			deploy.Spec.Template.Spec.SecurityContext = settings.PodSecurityContext.DeepCopy()
+		}
+		if settings.SecurityContext != nil {
+			deploy.Spec.Template.Spec.Containers[0].SecurityContext = settings.SecurityContext.DeepCopy()
+		}
+		if settings.DNSPolicy != "" {
+			deploy.Spec.Template.Spec.DNSPolicy = settings.DNSPolicy
+		}
+		objutil.ApplyProbeSettings(deploy.Spec.Template.Spec.Containers[0].LivenessProbe, settings.LivenessProbe)
+		objutil.ApplyProbeSettings(deploy.Spec.Template.Spec.Containers[0].ReadinessProbe, settings.ReadinessProbe)
+		deploy.Spec.Template.Spec.DNSConfig = settings.DNSConfig.DeepCopy()
+		if settings.ProgressDeadlineSeconds != nil {
+			deploy.Spec.ProgressDeadlineSeconds = pointer.Int32Ptr(*settings.ProgressDeadlineSeconds)
+		}
+		deploy.Spec.Template.Spec.Volumes = append(deploy.Spec.Template.Spec.Volumes,
+			objutil.DefaultVolumes(settings.ExtraVolumes)...)
+		deploy.Spec.Template.Spec.Containers[0].VolumeMounts = append(deploy.Spec.Template.Spec.Containers[0].VolumeMounts,
+			settings.ExtraVolumeMounts...)
+		deploy.Spec.Template.Spec.Containers[0].Env = append(deploy.Spec.Template.Spec.Containers[0].Env,
+			objutil.DefaultEnv(settings.ExtraEnv)...)
+		deploy.Spec.Template.Spec.Containers = append(deploy.Spec.Template.Spec.Containers,
+			objutil.DefaultContainers(settings.ExtraContainers)...)
+		deploy.Spec.Template.Spec.InitContainers = objutil.DefaultContainers(settings.ExtraInitContainers)
+	}
+
+	// Errors rendering the configuration are reported when ensuring the
+	// ConfigMap, so the annotation is only set when rendering succeeds.
+	if hash, err := objcm.ConfigHash(contour); err == nil {
+		deploy.Spec.Template.Annotations[contourConfigHashAnnotation] = hash
+	}
+
+	if contour.ContourAffinityExists() {
+		affinity := contour.Spec.NodePlacement.Contour.Affinity.DeepCopy()
+		if affinity.PodAntiAffinity == nil {
+			affinity.PodAntiAffinity = deploy.Spec.Template.Spec.Affinity.PodAntiAffinity
+		}
+		deploy.Spec.Template.Spec.Affinity = affinity
+	}
+
+	if placement := contour.Spec.NodePlacement; placement != nil && placement.Contour != nil {
+		deploy.Spec.Template.Spec.TopologySpreadConstraints = objutil.TopologySpreadConstraints(
+			placement.Contour.TopologySpreadConstraints, ContourDeploymentPodSelector())
+	}
+
 	return deploy
 }
 
@@ -349,6 +449,8 @@ func updateDeploymentIfNeeded(ctx context.Context, cli client.Client, contour *o
 	if labels.Exist(current, objcontour.OwnerLabels(contour)) {
 		deploy, updated := equality.DeploymentConfigChanged(current, desired)
 		if updated {
+			log.FromContext(ctx).Info("updating deployment", "namespace", deploy.Namespace, "name", deploy.Name,
+				"diff", equality.Diff(current.Spec, deploy.Spec))
 			if err := cli.Update(ctx, deploy); err != nil {
 				return fmt.Errorf("failed to update deployment %s/%s: %w", deploy.Namespace, deploy.Name, err)
 			}
@@ -374,6 +476,52 @@ func makeDeploymentLabels(contour *operatorv1alpha1.Contour) map[string]string {
 	return labels
 }
 
+// ReservedVolumeNames returns the names of the volumes the operator manages
+// in Contour pods.
+func ReservedVolumeNames() []string {
+	return []string{contourCertsVolName, contourCfgVolName, contourMetricsCertsVolName}
+}
+
+// ReservedContainerNames returns the names of the containers the operator
+// manages in Contour pods.
+func ReservedContainerNames() []string {
+	return []string{contourContainerName}
+}
+
+// ReservedPorts returns the port numbers the operator binds in Contour pods.
+func ReservedPorts() []int32 {
+	return []int32{objcfg.XDSPort, metricsPort, debugPort, healthPort}
+}
+
+// ReservedEnvVarNames returns the names of the environment variables the
+// operator manages in the Contour container.
+func ReservedEnvVarNames() []string {
+	return []string{contourNsEnvVar, contourPodEnvVar}
+}
+
+// contourStrategy returns the update strategy of the Contour Deployment for
+// contour, with unset rolling update fields defaulted.
+func contourStrategy(contour *operatorv1alpha1.Contour) appsv1.DeploymentStrategy {
+	strategy := appsv1.DeploymentStrategy{}
+	if contour.Spec.Contour != nil && contour.Spec.Contour.Strategy != nil {
+		contour.Spec.Contour.Strategy.DeepCopyInto(&strategy)
+	}
+	if strategy.Type == appsv1.RecreateDeploymentStrategyType {
+		return strategy
+	}
+	strategy.Type = appsv1.RollingUpdateDeploymentStrategyType
+	if strategy.RollingUpdate == nil {
+		strategy.RollingUpdate = &appsv1.RollingUpdateDeployment{}
+	}
+	if strategy.RollingUpdate.MaxSurge == nil {
+		strategy.RollingUpdate.MaxSurge = opintstr.PointerTo(intstr.FromString("50%"))
+	}
+	if strategy.RollingUpdate.MaxUnavailable == nil {
+		strategy.RollingUpdate.MaxUnavailable = opintstr.PointerTo(intstr.FromString("25%"))
+	}
+	return strategy
+}
+
 // ContourDeploymentPodSelector returns a label selector using "app: contour" as the
 // key/value pair.
 //
@@ -24,6 +24,9 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/pointer"
 )
 
 func checkDeploymentHasEnvVar(t *testing.T, deploy *appsv1.Deployment, name string) {
@@ -79,6 +82,16 @@ func checkContainerHasArg(t *testing.T, container *corev1.Container, arg string)
 	t.Errorf("container is missing argument %q", arg)
 }
 
+func checkContainerDoesNotHaveArg(t *testing.T, container *corev1.Container, arg string) {
+	t.Helper()
+
+	for _, a := range container.Args {
+		if a == arg {
+			t.Errorf("container has unexpected argument %q", arg)
+		}
+	}
+}
+
 func checkContainerHasImage(t *testing.T, container *corev1.Container, image string) {
 	t.Helper()
 
@@ -106,6 +119,15 @@ func checkDeploymentHasTolerations(t *testing.T, deploy *appsv1.Deployment, expe
 	t.Errorf("deployment has unexpected tolerations %v", expected)
 }
 
+func checkDeploymentHasAffinity(t *testing.T, deploy *appsv1.Deployment, expected *corev1.Affinity) {
+	t.Helper()
+
+	if apiequality.Semantic.DeepEqual(deploy.Spec.Template.Spec.Affinity, expected) {
+		return
+	}
+	t.Errorf("deployment has unexpected affinity %v", deploy.Spec.Template.Spec.Affinity)
+}
+
 func TestDesiredDeployment(t *testing.T) {
 	name := "deploy-test"
 	cfg := objcontour.Config{
@@ -152,8 +174,64 @@ func TestDesiredDeployment(t *testing.T) {
 
 	arg := fmt.Sprintf("--ingress-class-name=%s", *cntr.Spec.IngressClassName)
 	checkContainerHasArg(t, container, arg)
+	checkContainerDoesNotHaveArg(t, container, "--root-namespaces")
 	checkDeploymentHasNodeSelector(t, deploy, nil)
 	checkDeploymentHasTolerations(t, deploy, nil)
+	checkContainerDoesNotHaveArg(t, container, "--use-proxy-protocol")
+
+	// The PROXY protocol is enabled by default for AWS Classic load balancers.
+	cntr.Spec.NetworkPublishing.Envoy.LoadBalancer.ProviderParameters.Type = operatorv1alpha1.AWSLoadBalancerProvider
+	deploy = DesiredDeployment(cntr, testContourImage)
+	container = checkDeploymentHasContainer(t, deploy, contourContainerName, true)
+	checkContainerHasArg(t, container, "--use-proxy-protocol")
+
+	cntr.Spec.Contour = &operatorv1alpha1.ContourSettings{
+		PodLabels:         map[string]string{"app": "other", "cost-center": "ingress"},
+		PodAnnotations:    map[string]string{"prometheus.io/scrape": "false"},
+		PriorityClassName: "system-cluster-critical",
+	}
+	deploy = DesiredDeployment(cntr, testContourImage)
+	if deploy.Spec.Template.Spec.PriorityClassName != "system-cluster-critical" {
+		t.Errorf("deployment has unexpected priority class %q", deploy.Spec.Template.Spec.PriorityClassName)
+	}
+	if deploy.Spec.Template.Labels["app"] != "contour" || deploy.Spec.Template.Labels["cost-center"] != "ingress" {
+		t.Errorf("deployment has unexpected pod labels %v", deploy.Spec.Template.Labels)
+	}
+	if deploy.Spec.Template.Annotations["prometheus.io/scrape"] != "false" {
+		t.Errorf("deployment has unexpected pod annotations %v", deploy.Spec.Template.Annotations)
+	}
+
+	resources := corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m")},
+		Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("256Mi")},
+	}
+	cntr.Spec.Resources = &operatorv1alpha1.ContainerResources{Contour: resources}
+	deploy = DesiredDeployment(cntr, testContourImage)
+	container = checkDeploymentHasContainer(t, deploy, contourContainerName, true)
+	if !apiequality.Semantic.DeepEqual(container.Resources, resources) {
+		t.Errorf("contour container has unexpected resources %v", container.Resources)
+	}
+	checkContainerDoesNotHaveArg(t, container, fmt.Sprintf("--health-port=%d", healthPort))
+
+	cntr.Spec.Contour.MetricsTLS = &operatorv1alpha1.MetricsTLS{SecretName: "metrics-tls"}
+	deploy = DesiredDeployment(cntr, testContourImage)
+	container = checkDeploymentHasContainer(t, deploy, contourContainerName, true)
+	checkContainerHasArg(t, container, fmt.Sprintf("--health-port=%d", healthPort))
+	if port := container.LivenessProbe.HTTPGet.Port.IntVal; port != healthPort {
+		t.Errorf("contour container has liveness probe port %d; expected %d", port, healthPort)
+	}
+	if deploy.Spec.Template.Annotations["prometheus.io/scheme"] != "https" {
+		t.Errorf("deployment has unexpected pod annotations %v", deploy.Spec.Template.Annotations)
+	}
+	foundVolume := false
+	for _, vol := range deploy.Spec.Template.Spec.Volumes {
+		if vol.Name == contourMetricsCertsVolName && vol.Secret != nil && vol.Secret.SecretName == "metrics-tls" {
+			foundVolume = true
+		}
+	}
+	if !foundVolume {
+		t.Errorf("deployment is missing the metrics certificate volume")
+	}
 }
 
 func TestNodePlacementDeployment(t *testing.T) {
@@ -186,4 +264,292 @@ func TestNodePlacementDeployment(t *testing.T) {
 
 	checkDeploymentHasNodeSelector(t, deploy, selectors)
 	checkDeploymentHasTolerations(t, deploy, tolerations)
+
+	nodeAffinity := &corev1.NodeAffinity{
+		RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+			NodeSelectorTerms: []corev1.NodeSelectorTerm{
+				{
+					MatchExpressions: []corev1.NodeSelectorRequirement{
+						{
+							Key:      "node-role.kubernetes.io/infra",
+							Operator: corev1.NodeSelectorOpExists,
+						},
+					},
+				},
+			},
+		},
+	}
+	defaultAntiAffinity := deploy.Spec.Template.Spec.Affinity.PodAntiAffinity
+	cntr.Spec.NodePlacement.Contour.Affinity = &corev1.Affinity{NodeAffinity: nodeAffinity}
+	deploy = DesiredDeployment(cntr, "ghcr.io/projectcontour/contour:test")
+	checkDeploymentHasAffinity(t, deploy, &corev1.Affinity{
+		NodeAffinity:    nodeAffinity,
+		PodAntiAffinity: defaultAntiAffinity,
+	})
+
+	antiAffinity := &corev1.PodAntiAffinity{
+		RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{
+			{
+				TopologyKey:   "kubernetes.io/hostname",
+				LabelSelector: ContourDeploymentPodSelector(),
+			},
+		},
+	}
+	cntr.Spec.NodePlacement.Contour.Affinity.PodAntiAffinity = antiAffinity
+	deploy = DesiredDeployment(cntr, "ghcr.io/projectcontour/contour:test")
+	checkDeploymentHasAffinity(t, deploy, &corev1.Affinity{
+		NodeAffinity:    nodeAffinity,
+		PodAntiAffinity: antiAffinity,
+	})
+
+	cntr.Spec.NodePlacement.Contour.TopologySpreadConstraints = []corev1.TopologySpreadConstraint{
+		{
+			MaxSkew:           1,
+			TopologyKey:       "topology.kubernetes.io/zone",
+			WhenUnsatisfiable: corev1.DoNotSchedule,
+		},
+	}
+	deploy = DesiredDeployment(cntr, "ghcr.io/projectcontour/contour:test")
+	constraints := deploy.Spec.Template.Spec.TopologySpreadConstraints
+	if len(constraints) != 1 || constraints[0].TopologyKey != "topology.kubernetes.io/zone" {
+		t.Fatalf("deployment has topology spread constraints %v; expected a zone constraint", constraints)
+	}
+	if !apiequality.Semantic.DeepEqual(constraints[0].LabelSelector, ContourDeploymentPodSelector()) {
+		t.Errorf("deployment topology spread constraint has label selector %v; expected %v",
+			constraints[0].LabelSelector, ContourDeploymentPodSelector())
+	}
+}
+
+func TestContourStrategy(t *testing.T) {
+	name := "strategy-test"
+	cfg := objcontour.Config{
+		Name:        name,
+		Namespace:   fmt.Sprintf("%s-ns", name),
+		SpecNs:      "projectcontour",
+		RemoveNs:    false,
+		NetworkType: operatorv1alpha1.LoadBalancerServicePublishingType,
+	}
+	cntr := objcontour.New(cfg)
+
+	deploy := DesiredDeployment(cntr, "ghcr.io/projectcontour/contour:test")
+	rolling := deploy.Spec.Strategy.RollingUpdate
+	if rolling.MaxSurge.String() != "50%" || rolling.MaxUnavailable.String() != "25%" {
+		t.Errorf("deployment has unexpected default rolling update %v", rolling)
+	}
+	if *deploy.Spec.ProgressDeadlineSeconds != 600 {
+		t.Errorf("deployment has progress deadline %d; expected 600", *deploy.Spec.ProgressDeadlineSeconds)
+	}
+
+	maxUnavailable := intstr.FromInt(0)
+	cntr.Spec.Contour = &operatorv1alpha1.ContourSettings{
+		Strategy: &appsv1.DeploymentStrategy{
+			RollingUpdate: &appsv1.RollingUpdateDeployment{MaxUnavailable: &maxUnavailable},
+		},
+		ProgressDeadlineSeconds: pointer.Int32Ptr(1200),
+	}
+	deploy = DesiredDeployment(cntr, "ghcr.io/projectcontour/contour:test")
+	rolling = deploy.Spec.Strategy.RollingUpdate
+	if deploy.Spec.Strategy.Type != appsv1.RollingUpdateDeploymentStrategyType ||
+		rolling.MaxSurge.String() != "50%" || rolling.MaxUnavailable.IntValue() != 0 {
+		t.Errorf("deployment has unexpected strategy %v", deploy.Spec.Strategy)
+	}
+	if *deploy.Spec.ProgressDeadlineSeconds != 1200 {
+		t.Errorf("deployment has progress deadline %d; expected 1200", *deploy.Spec.ProgressDeadlineSeconds)
+	}
+
+	cntr.Spec.Contour.Strategy = &appsv1.DeploymentStrategy{Type: appsv1.RecreateDeploymentStrategyType}
+	deploy = DesiredDeployment(cntr, "ghcr.io/projectcontour/contour:test")
+	if deploy.Spec.Strategy.Type != appsv1.RecreateDeploymentStrategyType || deploy.Spec.Strategy.RollingUpdate != nil {
+		t.Errorf("deployment has unexpected strategy %v", deploy.Spec.Strategy)
+	}
+}
+
+func TestContourExtraVolumes(t *testing.T) {
+	name := "volumes-test"
+	cfg := objcontour.Config{
+		Name:        name,
+		Namespace:   fmt.Sprintf("%s-ns", name),
+		SpecNs:      "projectcontour",
+		RemoveNs:    false,
+		NetworkType: operatorv1alpha1.LoadBalancerServicePublishingType,
+	}
+	cntr := objcontour.New(cfg)
+	cntr.Spec.Contour = &operatorv1alpha1.ContourSettings{
+		ExtraVolumes: []corev1.Volume{{
+			Name: "extra",
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "extra"},
+				},
+			},
+		}},
+		ExtraVolumeMounts: []corev1.VolumeMount{{Name: "extra", MountPath: "/extra", ReadOnly: true}},
+	}
+
+	deploy := DesiredDeployment(cntr, "ghcr.io/projectcontour/contour:test")
+	volumes := deploy.Spec.Template.Spec.Volumes
+	if vol := volumes[len(volumes)-1]; vol.Name != "extra" || vol.ConfigMap == nil || vol.ConfigMap.DefaultMode == nil {
+		t.Errorf("deployment has unexpected extra volume %v", vol)
+	}
+	mounts := deploy.Spec.Template.Spec.Containers[0].VolumeMounts
+	if mount := mounts[len(mounts)-1]; mount.Name != "extra" || mount.MountPath != "/extra" {
+		t.Errorf("contour container has unexpected extra volume mount %v", mount)
+	}
+}
+
+func TestContourExtraEnv(t *testing.T) {
+	name := "env-test"
+	cfg := objcontour.Config{
+		Name:        name,
+		Namespace:   fmt.Sprintf("%s-ns", name),
+		SpecNs:      "projectcontour",
+		RemoveNs:    false,
+		NetworkType: operatorv1alpha1.LoadBalancerServicePublishingType,
+	}
+	cntr := objcontour.New(cfg)
+	cntr.Spec.Contour = &operatorv1alpha1.ContourSettings{
+		ExtraEnv: []corev1.EnvVar{
+			{Name: "GOMAXPROCS", Value: "2"},
+			{
+				Name: "HTTP_PROXY",
+				ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "proxy"},
+						Key:                  "url",
+					},
+				},
+			},
+		},
+	}
+
+	deploy := DesiredDeployment(cntr, "ghcr.io/projectcontour/contour:test")
+	checkDeploymentHasEnvVar(t, deploy, contourNsEnvVar)
+	checkDeploymentHasEnvVar(t, deploy, "GOMAXPROCS")
+	checkDeploymentHasEnvVar(t, deploy, "HTTP_PROXY")
+}
+
+func TestContourExtraContainers(t *testing.T) {
+	name := "containers-test"
+	cfg := objcontour.Config{
+		Name:        name,
+		Namespace:   fmt.Sprintf("%s-ns", name),
+		SpecNs:      "projectcontour",
+		RemoveNs:    false,
+		NetworkType: operatorv1alpha1.LoadBalancerServicePublishingType,
+	}
+	cntr := objcontour.New(cfg)
+	cntr.Spec.Contour = &operatorv1alpha1.ContourSettings{
+		ExtraContainers:     []corev1.Container{{Name: "audit", Image: "example.com/audit:v1"}},
+		ExtraInitContainers: []corev1.Container{{Name: "setup", Image: "example.com/setup:v1"}},
+	}
+
+	deploy := DesiredDeployment(cntr, "ghcr.io/projectcontour/contour:test")
+	checkDeploymentHasContainer(t, deploy, contourContainerName, true)
+	container := checkDeploymentHasContainer(t, deploy, "audit", true)
+	checkContainerHasImage(t, container, "example.com/audit:v1")
+	if len(deploy.Spec.Template.Spec.InitContainers) != 1 || deploy.Spec.Template.Spec.InitContainers[0].Name != "setup" {
+		t.Errorf("deployment has unexpected init containers %v", deploy.Spec.Template.Spec.InitContainers)
+	}
+}
+
+func TestContourSecurityContext(t *testing.T) {
+	name := "security-test"
+	cfg := objcontour.Config{
+		Name:        name,
+		Namespace:   fmt.Sprintf("%s-ns", name),
+		SpecNs:      "projectcontour",
+		RemoveNs:    false,
+		NetworkType: operatorv1alpha1.LoadBalancerServicePublishingType,
+	}
+	cntr := objcontour.New(cfg)
+
+	deploy := DesiredDeployment(cntr, "ghcr.io/projectcontour/contour:test")
+	podSC := deploy.Spec.Template.Spec.SecurityContext
+	if podSC.SeccompProfile == nil || podSC.SeccompProfile.Type != corev1.SeccompProfileTypeRuntimeDefault {
+		t.Errorf("deployment has unexpected security context %v", podSC)
+	}
+	sc := deploy.Spec.Template.Spec.Containers[0].SecurityContext
+	if sc == nil || sc.AllowPrivilegeEscalation == nil || *sc.AllowPrivilegeEscalation {
+		t.Errorf("contour container has unexpected security context %v", sc)
+	}
+
+	cntr.Spec.Contour = &operatorv1alpha1.ContourSettings{
+		PodSecurityContext: &corev1.PodSecurityContext{RunAsUser: pointer.Int64Ptr(1000)},
+		SecurityContext:    &corev1.SecurityContext{ReadOnlyRootFilesystem: pointer.BoolPtr(true)},
+	}
+	deploy = DesiredDeployment(cntr, "ghcr.io/projectcontour/contour:test")
+	if !apiequality.Semantic.DeepEqual(deploy.Spec.Template.Spec.SecurityContext, cntr.Spec.Contour.PodSecurityContext) {
+		t.Errorf("deployment has unexpected security context %v", deploy.Spec.Template.Spec.SecurityContext)
+	}
+	if !apiequality.Semantic.DeepEqual(deploy.Spec.Template.Spec.Containers[0].SecurityContext, cntr.Spec.Contour.SecurityContext) {
+		t.Errorf("contour container has unexpected security context %v", deploy.Spec.Template.Spec.Containers[0].SecurityContext)
+	}
+}
+
+func TestContourProbes(t *testing.T) {
+	name := "probes-test"
+	cfg := objcontour.Config{
+		Name:        name,
+		Namespace:   fmt.Sprintf("%s-ns", name),
+		SpecNs:      "projectcontour",
+		RemoveNs:    false,
+		NetworkType: operatorv1alpha1.LoadBalancerServicePublishingType,
+	}
+	cntr := objcontour.New(cfg)
+	cntr.Spec.Contour = &operatorv1alpha1.ContourSettings{
+		LivenessProbe:  &operatorv1alpha1.ProbeSettings{TimeoutSeconds: pointer.Int32Ptr(5)},
+		ReadinessProbe: &operatorv1alpha1.ProbeSettings{PeriodSeconds: pointer.Int32Ptr(30)},
+	}
+
+	deploy := DesiredDeployment(cntr, "ghcr.io/projectcontour/contour:test")
+	container := deploy.Spec.Template.Spec.Containers[0]
+	if probe := container.LivenessProbe; probe.TimeoutSeconds != 5 || probe.PeriodSeconds != 10 {
+		t.Errorf("contour container has unexpected liveness probe %v", probe)
+	}
+	if probe := container.ReadinessProbe; probe.PeriodSeconds != 30 || probe.InitialDelaySeconds != 15 {
+		t.Errorf("contour container has unexpected readiness probe %v", probe)
+	}
+}
+
+func TestContourConfigHash(t *testing.T) {
+	name := "config-hash-test"
+	cfg := objcontour.Config{
+		Name:        name,
+		Namespace:   fmt.Sprintf("%s-ns", name),
+		SpecNs:      "projectcontour",
+		RemoveNs:    false,
+		NetworkType: operatorv1alpha1.LoadBalancerServicePublishingType,
+	}
+	cntr := objcontour.New(cfg)
+
+	deploy := DesiredDeployment(cntr, "ghcr.io/projectcontour/contour:test")
+	hash := deploy.Spec.Template.Annotations[contourConfigHashAnnotation]
+	if hash == "" {
+		t.Fatalf("deployment is missing annotation %q", contourConfigHashAnnotation)
+	}
+
+	cntr.Spec.Envoy = &operatorv1alpha1.EnvoySettings{
+		Timeouts: &operatorv1alpha1.EnvoyTimeouts{ConnectionIdleTimeout: "2m"},
+	}
+	deploy = DesiredDeployment(cntr, "ghcr.io/projectcontour/contour:test")
+	if deploy.Spec.Template.Annotations[contourConfigHashAnnotation] == hash {
+		t.Errorf("expected annotation %q to change with contour configuration", contourConfigHashAnnotation)
+	}
+}
+
+func TestContourRootNamespaces(t *testing.T) {
+	name := "root-namespaces-test"
+	cfg := objcontour.Config{
+		Name:        name,
+		Namespace:   fmt.Sprintf("%s-ns", name),
+		SpecNs:      "projectcontour",
+		RemoveNs:    false,
+		NetworkType: operatorv1alpha1.LoadBalancerServicePublishingType,
+	}
+	cntr := objcontour.New(cfg)
+	cntr.Spec.RootNamespaces = []string{"team-a", "team-b"}
+
+	deploy := DesiredDeployment(cntr, "ghcr.io/projectcontour/contour:test")
+	container := checkDeploymentHasContainer(t, deploy, contourContainerName, true)
+	checkContainerHasArg(t, container, "--root-namespaces=team-a,team-b")
 }
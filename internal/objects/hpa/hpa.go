@@ -0,0 +1,186 @@
+// Copyright Project Contour Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hpa
+
+import (
+	"context"
+	"fmt"
+
+	operatorv1alpha1 "github.com/projectcontour/contour-operator/api/v1alpha1"
+	"github.com/projectcontour/contour-operator/internal/equality"
+	objcontour "github.com/projectcontour/contour-operator/internal/objects/contour"
+	objds "github.com/projectcontour/contour-operator/internal/objects/daemonset"
+	"github.com/projectcontour/contour-operator/pkg/labels"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// envoyHPAName is the name of Envoy's HorizontalPodAutoscaler resource.
+	envoyHPAName = objds.EnvoyDeploymentName
+	// defaultMinReplicas is the lower limit for the number of Envoy replicas
+	// when unspecified.
+	defaultMinReplicas = int32(1)
+	// defaultTargetCPUUtilization is the target average CPU utilization of
+	// Envoy pods when no metrics are specified.
+	defaultTargetCPUUtilization = int32(80)
+)
+
+// EnsureEnvoyHPA ensures a HorizontalPodAutoscaler exists for the Envoy
+// Deployment of the given contour.
+func EnsureEnvoyHPA(ctx context.Context, cli client.Client, contour *operatorv1alpha1.Contour) error {
+	desired := DesiredEnvoyHPA(contour)
+	current, err := CurrentEnvoyHPA(ctx, cli, contour)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return createHPA(ctx, cli, desired)
+		}
+		return fmt.Errorf("failed to get horizontalpodautoscaler %s/%s: %w", desired.Namespace, desired.Name, err)
+	}
+	if err := updateHPAIfNeeded(ctx, cli, contour, current, desired); err != nil {
+		return fmt.Errorf("failed to update horizontalpodautoscaler for contour %s/%s: %w", contour.Namespace, contour.Name, err)
+	}
+	return nil
+}
+
+// EnsureEnvoyHPADeleted ensures the HorizontalPodAutoscaler for the provided
+// contour is deleted if Contour owner labels exist.
+func EnsureEnvoyHPADeleted(ctx context.Context, cli client.Client, contour *operatorv1alpha1.Contour) error {
+	hpa, err := CurrentEnvoyHPA(ctx, cli, contour)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if labels.Exist(hpa, objcontour.OwnerLabels(contour)) {
+		if err := cli.Delete(ctx, hpa); err != nil {
+			if errors.IsNotFound(err) {
+				return nil
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// DesiredEnvoyHPA returns the desired HorizontalPodAutoscaler targeting the
+// Envoy Deployment of the provided contour.
+func DesiredEnvoyHPA(contour *operatorv1alpha1.Contour) *autoscalingv2.HorizontalPodAutoscaler {
+	autoscaling := contour.Spec.Envoy.Autoscaling
+
+	minReplicas := defaultMinReplicas
+	if autoscaling.MinReplicas != nil {
+		minReplicas = *autoscaling.MinReplicas
+	}
+
+	var metrics []autoscalingv2.MetricSpec
+	if autoscaling.TargetCPUUtilizationPercentage != nil {
+		metrics = append(metrics, resourceMetric(corev1.ResourceCPU, *autoscaling.TargetCPUUtilizationPercentage))
+	}
+	if autoscaling.TargetMemoryUtilizationPercentage != nil {
+		metrics = append(metrics, resourceMetric(corev1.ResourceMemory, *autoscaling.TargetMemoryUtilizationPercentage))
+	}
+	metrics = append(metrics, autoscaling.Metrics...)
+	// Set the API server default explicitly so it doesn't show up as a change.
+	if len(metrics) == 0 {
+		metrics = append(metrics, resourceMetric(corev1.ResourceCPU, defaultTargetCPUUtilization))
+	}
+
+	labels := map[string]string{
+		"app.kubernetes.io/name":       "contour",
+		"app.kubernetes.io/instance":   contour.Name,
+		"app.kubernetes.io/component":  "ingress-controller",
+		"app.kubernetes.io/managed-by": "contour-operator",
+	}
+	// Add owner labels
+	for k, v := range objcontour.OwnerLabels(contour) {
+		labels[k] = v
+	}
+
+	return &autoscalingv2.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: contour.Spec.Namespace.Name,
+			Name:      envoyHPAName,
+			Labels:    labels,
+		},
+		Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{
+				APIVersion: "apps/v1",
+				Kind:       "Deployment",
+				Name:       objds.EnvoyDeploymentName,
+			},
+			MinReplicas: pointer.Int32Ptr(minReplicas),
+			MaxReplicas: autoscaling.MaxReplicas,
+			Metrics:     metrics,
+		},
+	}
+}
+
+// resourceMetric returns a metric targeting the average utilization of
+// resource by Envoy pods.
+func resourceMetric(resource corev1.ResourceName, utilization int32) autoscalingv2.MetricSpec {
+	return autoscalingv2.MetricSpec{
+		Type: autoscalingv2.ResourceMetricSourceType,
+		Resource: &autoscalingv2.ResourceMetricSource{
+			Name: resource,
+			Target: autoscalingv2.MetricTarget{
+				Type:               autoscalingv2.UtilizationMetricType,
+				AverageUtilization: pointer.Int32Ptr(utilization),
+			},
+		},
+	}
+}
+
+// CurrentEnvoyHPA returns the current HorizontalPodAutoscaler for the provided contour.
+func CurrentEnvoyHPA(ctx context.Context, cli client.Client, contour *operatorv1alpha1.Contour) (*autoscalingv2.HorizontalPodAutoscaler, error) {
+	hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+	key := types.NamespacedName{
+		Namespace: contour.Spec.Namespace.Name,
+		Name:      envoyHPAName,
+	}
+	if err := cli.Get(ctx, key, hpa); err != nil {
+		return nil, err
+	}
+	return hpa, nil
+}
+
+// createHPA creates a HorizontalPodAutoscaler resource for the provided hpa.
+func createHPA(ctx context.Context, cli client.Client, hpa *autoscalingv2.HorizontalPodAutoscaler) error {
+	if err := cli.Create(ctx, hpa); err != nil {
+		return fmt.Errorf("failed to create horizontalpodautoscaler %s/%s: %w", hpa.Namespace, hpa.Name, err)
+	}
+	return nil
+}
+
+// updateHPAIfNeeded updates a HorizontalPodAutoscaler if current does not match
+// desired, using contour to verify the existence of owner labels.
+func updateHPAIfNeeded(ctx context.Context, cli client.Client, contour *operatorv1alpha1.Contour, current, desired *autoscalingv2.HorizontalPodAutoscaler) error {
+	if labels.Exist(current, objcontour.OwnerLabels(contour)) {
+		hpa, updated := equality.HorizontalPodAutoscalerChanged(current, desired)
+		if updated {
+			if err := cli.Update(ctx, hpa); err != nil {
+				return fmt.Errorf("failed to update horizontalpodautoscaler %s/%s: %w", hpa.Namespace, hpa.Name, err)
+			}
+			return nil
+		}
+	}
+	return nil
+}
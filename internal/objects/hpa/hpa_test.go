@@ -0,0 +1,97 @@
+// Copyright Project Contour Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hpa
+
+import (
+	"fmt"
+	"testing"
+
+	operatorv1alpha1 "github.com/projectcontour/contour-operator/api/v1alpha1"
+	objcontour "github.com/projectcontour/contour-operator/internal/objects/contour"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/pointer"
+)
+
+func checkHPAHasResourceMetric(t *testing.T, hpa *autoscalingv2.HorizontalPodAutoscaler, name corev1.ResourceName, utilization int32) {
+	t.Helper()
+
+	for _, m := range hpa.Spec.Metrics {
+		if m.Resource != nil && m.Resource.Name == name {
+			if *m.Resource.Target.AverageUtilization != utilization {
+				t.Errorf("hpa has %s utilization %d; expected %d", name, *m.Resource.Target.AverageUtilization, utilization)
+			}
+			return
+		}
+	}
+	t.Errorf("hpa is missing %s metric", name)
+}
+
+func TestDesiredEnvoyHPA(t *testing.T) {
+	name := "hpa-test"
+	cfg := objcontour.Config{
+		Name:        name,
+		Namespace:   fmt.Sprintf("%s-ns", name),
+		SpecNs:      "projectcontour",
+		RemoveNs:    false,
+		NetworkType: operatorv1alpha1.LoadBalancerServicePublishingType,
+	}
+	cntr := objcontour.New(cfg)
+	cntr.Spec.Envoy = &operatorv1alpha1.EnvoySettings{
+		WorkloadType: operatorv1alpha1.DeploymentEnvoyWorkload,
+		Autoscaling: &operatorv1alpha1.EnvoyAutoscaling{
+			MaxReplicas: 10,
+		},
+	}
+
+	hpa := DesiredEnvoyHPA(cntr)
+	if hpa.Namespace != cfg.SpecNs {
+		t.Errorf("hpa has namespace %q; expected %q", hpa.Namespace, cfg.SpecNs)
+	}
+	if hpa.Spec.ScaleTargetRef.Kind != "Deployment" || hpa.Spec.ScaleTargetRef.Name != "envoy" {
+		t.Errorf("hpa has unexpected scale target %v", hpa.Spec.ScaleTargetRef)
+	}
+	if *hpa.Spec.MinReplicas != defaultMinReplicas {
+		t.Errorf("hpa has min replicas %d; expected %d", *hpa.Spec.MinReplicas, defaultMinReplicas)
+	}
+	if hpa.Spec.MaxReplicas != 10 {
+		t.Errorf("hpa has max replicas %d; expected 10", hpa.Spec.MaxReplicas)
+	}
+	if len(hpa.Spec.Metrics) != 1 {
+		t.Errorf("hpa has %d metrics; expected 1", len(hpa.Spec.Metrics))
+	}
+	checkHPAHasResourceMetric(t, hpa, corev1.ResourceCPU, defaultTargetCPUUtilization)
+
+	cntr.Spec.Envoy.Autoscaling.MinReplicas = pointer.Int32Ptr(3)
+	cntr.Spec.Envoy.Autoscaling.TargetCPUUtilizationPercentage = pointer.Int32Ptr(60)
+	cntr.Spec.Envoy.Autoscaling.TargetMemoryUtilizationPercentage = pointer.Int32Ptr(70)
+	cntr.Spec.Envoy.Autoscaling.Metrics = []autoscalingv2.MetricSpec{
+		{
+			Type: autoscalingv2.PodsMetricSourceType,
+			Pods: &autoscalingv2.PodsMetricSource{
+				Metric: autoscalingv2.MetricIdentifier{Name: "envoy_http_downstream_rq_active"},
+			},
+		},
+	}
+	hpa = DesiredEnvoyHPA(cntr)
+	if *hpa.Spec.MinReplicas != 3 {
+		t.Errorf("hpa has min replicas %d; expected 3", *hpa.Spec.MinReplicas)
+	}
+	if len(hpa.Spec.Metrics) != 3 {
+		t.Errorf("hpa has %d metrics; expected 3", len(hpa.Spec.Metrics))
+	}
+	checkHPAHasResourceMetric(t, hpa, corev1.ResourceCPU, 60)
+	checkHPAHasResourceMetric(t, hpa, corev1.ResourceMemory, 70)
+}
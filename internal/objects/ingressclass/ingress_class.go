@@ -0,0 +1,139 @@
+// Copyright Project Contour Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ingressclass
+
+import (
+	"context"
+	"fmt"
+
+	operatorv1alpha1 "github.com/projectcontour/contour-operator/api/v1alpha1"
+	"github.com/projectcontour/contour-operator/internal/equality"
+	objcontour "github.com/projectcontour/contour-operator/internal/objects/contour"
+	"github.com/projectcontour/contour-operator/pkg/labels"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// ContourIngressController is the controller name of IngressClasses
+	// reconciled by Contour.
+	ContourIngressController = "projectcontour.io/ingress-controller"
+)
+
+// EnsureIngressClass ensures an IngressClass named spec.ingressClassName exists
+// for the given contour when spec.createIngressClass is set, and removes
+// IngressClasses previously created for the contour under other names.
+func EnsureIngressClass(ctx context.Context, cli client.Client, contour *operatorv1alpha1.Contour) error {
+	name := ""
+	if contour.Spec.CreateIngressClass && contour.Spec.IngressClassName != nil {
+		name = *contour.Spec.IngressClassName
+	}
+	owned, err := currentOwnedIngressClasses(ctx, cli, contour)
+	if err != nil {
+		return fmt.Errorf("failed to list ingress classes: %w", err)
+	}
+	for i := range owned {
+		if owned[i].Name == name {
+			continue
+		}
+		if err := deleteIngressClass(ctx, cli, &owned[i]); err != nil {
+			return err
+		}
+	}
+	if name == "" {
+		return nil
+	}
+
+	desired := DesiredIngressClass(contour, name)
+	current, err := CurrentIngressClass(ctx, cli, name)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			if err := cli.Create(ctx, desired); err != nil {
+				return fmt.Errorf("failed to create ingress class %s: %w", desired.Name, err)
+			}
+			return nil
+		}
+		return fmt.Errorf("failed to get ingress class %s: %w", name, err)
+	}
+	if labels.Exist(current, objcontour.OwnerLabels(contour)) {
+		if updated, changed := equality.IngressClassConfigChanged(current, desired); changed {
+			if err := cli.Update(ctx, updated); err != nil {
+				return fmt.Errorf("failed to update ingress class %s: %w", updated.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// EnsureIngressClassDeleted ensures the IngressClasses created for the
+// provided contour are deleted.
+func EnsureIngressClassDeleted(ctx context.Context, cli client.Client, contour *operatorv1alpha1.Contour) error {
+	owned, err := currentOwnedIngressClasses(ctx, cli, contour)
+	if err != nil {
+		return err
+	}
+	for i := range owned {
+		if err := deleteIngressClass(ctx, cli, &owned[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DesiredIngressClass returns the desired IngressClass named name for the
+// provided contour.
+func DesiredIngressClass(contour *operatorv1alpha1.Contour, name string) *networkingv1.IngressClass {
+	return &networkingv1.IngressClass{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: objcontour.OwnerLabels(contour),
+		},
+		Spec: networkingv1.IngressClassSpec{
+			Controller: ContourIngressController,
+		},
+	}
+}
+
+// CurrentIngressClass returns the current IngressClass for the provided name.
+func CurrentIngressClass(ctx context.Context, cli client.Client, name string) (*networkingv1.IngressClass, error) {
+	current := &networkingv1.IngressClass{}
+	key := types.NamespacedName{Name: name}
+	if err := cli.Get(ctx, key, current); err != nil {
+		return nil, err
+	}
+	return current, nil
+}
+
+// currentOwnedIngressClasses returns the IngressClasses created for the
+// provided contour.
+func currentOwnedIngressClasses(ctx context.Context, cli client.Client, contour *operatorv1alpha1.Contour) ([]networkingv1.IngressClass, error) {
+	classes := &networkingv1.IngressClassList{}
+	if err := cli.List(ctx, classes, client.MatchingLabels(objcontour.OwnerLabels(contour))); err != nil {
+		return nil, err
+	}
+	return classes.Items, nil
+}
+
+// deleteIngressClass deletes the provided IngressClass, ignoring IngressClasses
+// that don't exist.
+func deleteIngressClass(ctx context.Context, cli client.Client, class *networkingv1.IngressClass) error {
+	if err := cli.Delete(ctx, class); err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete ingress class %s: %w", class.Name, err)
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+// Copyright Project Contour Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ingressclass
+
+import (
+	"fmt"
+	"testing"
+
+	operatorv1alpha1 "github.com/projectcontour/contour-operator/api/v1alpha1"
+	objcontour "github.com/projectcontour/contour-operator/internal/objects/contour"
+	"github.com/projectcontour/contour-operator/pkg/labels"
+)
+
+func TestDesiredIngressClass(t *testing.T) {
+	name := "ingressclass-test"
+	cfg := objcontour.Config{
+		Name:        name,
+		Namespace:   fmt.Sprintf("%s-ns", name),
+		SpecNs:      "projectcontour",
+		RemoveNs:    false,
+		NetworkType: operatorv1alpha1.LoadBalancerServicePublishingType,
+	}
+	cntr := objcontour.New(cfg)
+
+	class := DesiredIngressClass(cntr, "internal")
+	if class.Name != "internal" {
+		t.Errorf("ingress class has name %q; expected %q", class.Name, "internal")
+	}
+	if class.Spec.Controller != ContourIngressController {
+		t.Errorf("ingress class has controller %q; expected %q", class.Spec.Controller, ContourIngressController)
+	}
+	if !labels.Exist(class, objcontour.OwnerLabels(cntr)) {
+		t.Errorf("ingress class is missing owner labels")
+	}
+}
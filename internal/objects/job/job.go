@@ -110,25 +110,31 @@ func DesiredJob(contour *operatorv1alpha1.Contour, image string) *batchv1.Job {
 			},
 		},
 	}
+	command := []string{
+		"contour",
+		"certgen",
+		"--kube",
+		"--incluster",
+		"--overwrite",
+		"--secrets-format=compact",
+		fmt.Sprintf("--namespace=$(%s)", jobNsEnvVar),
+	}
+	if certgen := contour.Spec.Certgen; certgen != nil && certgen.CertificateLifetime != nil {
+		command = append(command, fmt.Sprintf("--certificate-lifetime=%d", *certgen.CertificateLifetime))
+	}
 	container := corev1.Container{
-		Name:            jobContainerName,
-		Image:           image,
-		ImagePullPolicy: corev1.PullAlways,
-		Command: []string{
-			"contour",
-			"certgen",
-			"--kube",
-			"--incluster",
-			"--overwrite",
-			"--secrets-format=compact",
-			fmt.Sprintf("--namespace=$(%s)", jobNsEnvVar),
-		},
-		Env:                      []corev1.EnvVar{env},
+		Name:                     jobContainerName,
+		Image:                    image,
+		ImagePullPolicy:          objutil.PullPolicyFor(contour.ContourImageOverride(), corev1.PullAlways),
+		Command:                  command,
+		Env:                      append([]corev1.EnvVar{env}, objutil.ProxyEnv(contour)...),
+		SecurityContext:          objutil.NewRestrictedContainerSecurity(),
 		TerminationMessagePath:   "/dev/termination-log",
 		TerminationMessagePolicy: "File",
 	}
 	spec := corev1.PodSpec{
 		Containers:                    []corev1.Container{container},
+		ImagePullSecrets:              contour.ImagePullSecrets(),
 		DeprecatedServiceAccount:      objutil.CertGenRbacName,
 		ServiceAccountName:            objutil.CertGenRbacName,
 		SecurityContext:               objutil.NewUnprivilegedPodSecurity(),
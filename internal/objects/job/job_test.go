@@ -15,6 +15,7 @@ package job
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
 	operatorv1alpha1 "github.com/projectcontour/contour-operator/api/v1alpha1"
@@ -22,6 +23,7 @@ import (
 
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/pointer"
 )
 
 func checkJobHasEnvVar(t *testing.T, job *batchv1.Job, name string) {
@@ -71,4 +73,27 @@ func TestDesiredJob(t *testing.T) {
 	container := checkJobHasContainer(t, job, jobContainerName)
 	checkContainerHasImage(t, container, testContourImage)
 	checkJobHasEnvVar(t, job, jobNsEnvVar)
+	for _, arg := range container.Command {
+		if strings.HasPrefix(arg, "--certificate-lifetime") {
+			t.Errorf("job has unexpected arg %q", arg)
+		}
+	}
+
+	cntr.Spec.Certgen = &operatorv1alpha1.CertgenSettings{CertificateLifetime: pointer.Int32Ptr(90)}
+	job = DesiredJob(cntr, testContourImage)
+	container = checkJobHasContainer(t, job, jobContainerName)
+	found := false
+	for _, arg := range container.Command {
+		if arg == "--certificate-lifetime=90" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("job is missing arg --certificate-lifetime=90")
+	}
+
+	cntr.Spec.ProxyConfig = &operatorv1alpha1.ProxyConfig{HTTPSProxy: "http://proxy.example.com:3128"}
+	job = DesiredJob(cntr, testContourImage)
+	checkJobHasEnvVar(t, job, "HTTPS_PROXY")
+	checkJobHasEnvVar(t, job, "NO_PROXY")
 }
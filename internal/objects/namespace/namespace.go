@@ -16,9 +16,12 @@ package namespace
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 
 	operatorv1alpha1 "github.com/projectcontour/contour-operator/api/v1alpha1"
 	"github.com/projectcontour/contour-operator/internal/equality"
+	objutil "github.com/projectcontour/contour-operator/internal/objects"
 	objcontour "github.com/projectcontour/contour-operator/internal/objects/contour"
 	"github.com/projectcontour/contour-operator/pkg/labels"
 
@@ -93,12 +96,41 @@ func EnsureNamespaceDeleted(ctx context.Context, cli client.Client, contour *ope
 
 // DesiredNamespace returns the desired Namespace resource for the provided contour.
 func DesiredNamespace(contour *operatorv1alpha1.Contour) *corev1.Namespace {
-	return &corev1.Namespace{
+	ns := &corev1.Namespace{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:   contour.Spec.Namespace.Name,
 			Labels: objcontour.OwnerLabels(contour),
 		},
 	}
+	objutil.MergePodMetadata(&ns.ObjectMeta, contour.Spec.Namespace.Labels, contour.Spec.Namespace.Annotations)
+	// Record the keys applied from spec.namespace so they can be removed
+	// once the contour no longer specifies them.
+	labelKeys := appliedKeys(ns.Labels, contour.Spec.Namespace.Labels)
+	annotationKeys := appliedKeys(ns.Annotations, contour.Spec.Namespace.Annotations)
+	if len(labelKeys) > 0 {
+		objutil.MergePodMetadata(&ns.ObjectMeta, nil, map[string]string{
+			operatorv1alpha1.ManagedNamespaceLabelsAnnotation: strings.Join(labelKeys, ","),
+		})
+	}
+	if len(annotationKeys) > 0 {
+		objutil.MergePodMetadata(&ns.ObjectMeta, nil, map[string]string{
+			operatorv1alpha1.ManagedNamespaceAnnotationsAnnotation: strings.Join(annotationKeys, ","),
+		})
+	}
+	return ns
+}
+
+// appliedKeys returns the sorted keys of specified that were applied to
+// applied, i.e. that weren't overridden by the operator.
+func appliedKeys(applied, specified map[string]string) []string {
+	var keys []string
+	for k, v := range specified {
+		if applied[k] == v {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
 }
 
 // createNamespace creates a Namespace resource for the provided ns.
@@ -62,3 +62,36 @@ func TestDesiredNamespace(t *testing.T) {
 	}
 	checkNamespaceLabels(t, ns, ownerLabels)
 }
+
+func TestDesiredNamespaceMetadata(t *testing.T) {
+	cntrName := "ns-test"
+	cfg := objcontour.Config{
+		Name:        cntrName,
+		Namespace:   fmt.Sprintf("%s-ns", cntrName),
+		SpecNs:      "projectcontour",
+		RemoveNs:    false,
+		NetworkType: operatorv1alpha1.LoadBalancerServicePublishingType,
+	}
+	cntr := objcontour.New(cfg)
+	cntr.Spec.Namespace.Labels = map[string]string{
+		"pod-security.kubernetes.io/enforce":    "baseline",
+		operatorv1alpha1.OwningContourNameLabel: "other",
+	}
+	cntr.Spec.Namespace.Annotations = map[string]string{"linkerd.io/inject": "disabled"}
+	ns := DesiredNamespace(cntr)
+	expected := map[string]string{
+		operatorv1alpha1.OwningContourNameLabel: cntr.Name,
+		operatorv1alpha1.OwningContourNsLabel:   cntr.Namespace,
+		"pod-security.kubernetes.io/enforce":    "baseline",
+	}
+	checkNamespaceLabels(t, ns, expected)
+	expectedAnnotations := map[string]string{
+		"linkerd.io/inject": "disabled",
+		// The owner label override isn't applied, so it isn't recorded.
+		operatorv1alpha1.ManagedNamespaceLabelsAnnotation:      "pod-security.kubernetes.io/enforce",
+		operatorv1alpha1.ManagedNamespaceAnnotationsAnnotation: "linkerd.io/inject",
+	}
+	if !apiequality.Semantic.DeepEqual(ns.Annotations, expectedAnnotations) {
+		t.Errorf("namespace has unexpected %q annotations", ns.Annotations)
+	}
+}
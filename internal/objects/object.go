@@ -16,11 +16,17 @@ package objects
 import (
 	"strings"
 
+	operatorv1alpha1 "github.com/projectcontour/contour-operator/api/v1alpha1"
+	"github.com/projectcontour/contour-operator/internal/equality"
+
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
 )
 
 // NewUnprivilegedPodSecurity makes a a non-root PodSecurityContext object
-// using 65534 as the user and group ID.
+// using 65534 as the user and group ID and the runtime's default seccomp
+// profile.
 func NewUnprivilegedPodSecurity() *corev1.PodSecurityContext {
 	user := int64(65534)
 	group := int64(65534)
@@ -29,15 +35,256 @@ func NewUnprivilegedPodSecurity() *corev1.PodSecurityContext {
 		RunAsUser:    &user,
 		RunAsGroup:   &group,
 		RunAsNonRoot: &nonRoot,
+		SeccompProfile: &corev1.SeccompProfile{
+			Type: corev1.SeccompProfileTypeRuntimeDefault,
+		},
+	}
+}
+
+// NewRestrictedContainerSecurity makes a SecurityContext object that drops
+// all capabilities and disallows privilege escalation, as required by the
+// "restricted" Pod Security Standard.
+func NewRestrictedContainerSecurity() *corev1.SecurityContext {
+	return &corev1.SecurityContext{
+		AllowPrivilegeEscalation: pointer.BoolPtr(false),
+		Capabilities: &corev1.Capabilities{
+			Drop: []corev1.Capability{"ALL"},
+		},
 	}
 }
 
 // TagFromImage returns the tag from the provided image or an
-// empty string if the image does not contain a tag.
+// empty string if the image does not contain a tag. Images referenced
+// only by digest return the first 12 characters of the digest instead,
+// so the result stays usable in object names.
 func TagFromImage(image string) string {
-	if strings.Contains(image, ":") {
-		parsed := strings.Split(image, ":")
-		return parsed[1]
+	_, ref := splitImage(image)
+	if i := strings.Index(ref, "@"); i >= 0 {
+		if i > 0 {
+			return ref[1:i]
+		}
+		digest := ref[strings.Index(ref, ":")+1:]
+		if len(digest) > 12 {
+			digest = digest[:12]
+		}
+		return digest
+	}
+	return strings.TrimPrefix(ref, ":")
+}
+
+// ImageFor returns the image resulting from applying override to image.
+// The repository of image is kept unless overridden, and its tag or digest
+// is replaced when override sets either.
+func ImageFor(image string, override *operatorv1alpha1.ImageOverride) string {
+	if override == nil {
+		return image
+	}
+	repo, ref := splitImage(image)
+	if override.Repository != "" {
+		repo = override.Repository
+	}
+	switch {
+	case override.Digest != "":
+		ref = "@" + override.Digest
+	case override.Tag != "":
+		ref = ":" + override.Tag
+	}
+	return repo + ref
+}
+
+// splitImage splits image into its repository and its ":tag" or "@digest"
+// reference, if any.
+func splitImage(image string) (string, string) {
+	repo, ref := image, ""
+	if i := strings.Index(repo, "@"); i >= 0 {
+		repo, ref = repo[:i], repo[i:]
+	}
+	// A colon before the last slash separates a registry port, not a tag.
+	if i := strings.LastIndex(repo, ":"); i > strings.LastIndex(repo, "/") {
+		repo, ref = repo[:i], repo[i:]+ref
+	}
+	return repo, ref
+}
+
+// PullPolicyFor returns the pull policy set by override, or policy if unset.
+func PullPolicyFor(override *operatorv1alpha1.ImageOverride, policy corev1.PullPolicy) corev1.PullPolicy {
+	if override != nil && override.PullPolicy != "" {
+		return override.PullPolicy
+	}
+	return policy
+}
+
+// MergePodMetadata adds labels and annotations to the pod template metadata
+// meta. Annotations replace existing ones, while existing labels are kept
+// since they select the pods of the owning workload.
+func MergePodMetadata(meta *metav1.ObjectMeta, labels, annotations map[string]string) {
+	for k, v := range labels {
+		if meta.Labels == nil {
+			meta.Labels = map[string]string{}
+		}
+		if _, found := meta.Labels[k]; !found {
+			meta.Labels[k] = v
+		}
+	}
+	for k, v := range annotations {
+		if meta.Annotations == nil {
+			meta.Annotations = map[string]string{}
+		}
+		meta.Annotations[k] = v
+	}
+}
+
+// TopologySpreadConstraints returns a copy of constraints where constraints
+// without a label selector select the pods matching selector.
+func TopologySpreadConstraints(constraints []corev1.TopologySpreadConstraint, selector *metav1.LabelSelector) []corev1.TopologySpreadConstraint {
+	if len(constraints) == 0 {
+		return nil
+	}
+	defaulted := make([]corev1.TopologySpreadConstraint, len(constraints))
+	for i := range constraints {
+		constraints[i].DeepCopyInto(&defaulted[i])
+		if defaulted[i].LabelSelector == nil {
+			defaulted[i].LabelSelector = selector.DeepCopy()
+		}
+	}
+	return defaulted
+}
+
+// defaultVolumeMode is the file mode the API server defaults for files
+// projected into volumes.
+const defaultVolumeMode = int32(420)
+
+// DefaultVolumes returns a copy of volumes with the fields the API server
+// defaults set explicitly, so that user-provided volumes don't cause the
+// desired and current pod templates to differ.
+func DefaultVolumes(volumes []corev1.Volume) []corev1.Volume {
+	if len(volumes) == 0 {
+		return nil
+	}
+	defaulted := make([]corev1.Volume, len(volumes))
+	for i := range volumes {
+		volumes[i].DeepCopyInto(&defaulted[i])
+		src := &defaulted[i].VolumeSource
+		switch {
+		case src.Secret != nil && src.Secret.DefaultMode == nil:
+			src.Secret.DefaultMode = pointer.Int32Ptr(defaultVolumeMode)
+		case src.ConfigMap != nil && src.ConfigMap.DefaultMode == nil:
+			src.ConfigMap.DefaultMode = pointer.Int32Ptr(defaultVolumeMode)
+		case src.Projected != nil && src.Projected.DefaultMode == nil:
+			src.Projected.DefaultMode = pointer.Int32Ptr(defaultVolumeMode)
+		case src.DownwardAPI != nil && src.DownwardAPI.DefaultMode == nil:
+			src.DownwardAPI.DefaultMode = pointer.Int32Ptr(defaultVolumeMode)
+		case src.HostPath != nil && src.HostPath.Type == nil:
+			unset := corev1.HostPathUnset
+			src.HostPath.Type = &unset
+		}
+	}
+	return defaulted
+}
+
+// DefaultEnv returns a copy of env with the fields the API server defaults
+// set explicitly, so that user-provided environment variables don't cause
+// the desired and current pod templates to differ.
+func DefaultEnv(env []corev1.EnvVar) []corev1.EnvVar {
+	if len(env) == 0 {
+		return nil
+	}
+	defaulted := make([]corev1.EnvVar, len(env))
+	for i := range env {
+		env[i].DeepCopyInto(&defaulted[i])
+		if from := defaulted[i].ValueFrom; from != nil && from.FieldRef != nil && from.FieldRef.APIVersion == "" {
+			from.FieldRef.APIVersion = "v1"
+		}
+	}
+	return defaulted
+}
+
+// DefaultContainers returns a copy of containers with the fields the API
+// server defaults set explicitly, so that user-provided containers don't
+// cause the desired and current pod templates to differ.
+func DefaultContainers(containers []corev1.Container) []corev1.Container {
+	if len(containers) == 0 {
+		return nil
+	}
+	defaulted := make([]corev1.Container, len(containers))
+	for i := range containers {
+		containers[i].DeepCopyInto(&defaulted[i])
+		c := &defaulted[i]
+		if c.ImagePullPolicy == "" {
+			c.ImagePullPolicy = defaultPullPolicy(c.Image)
+		}
+		if c.TerminationMessagePath == "" {
+			c.TerminationMessagePath = corev1.TerminationMessagePathDefault
+		}
+		if c.TerminationMessagePolicy == "" {
+			c.TerminationMessagePolicy = corev1.TerminationMessageReadFile
+		}
+		for j := range c.Ports {
+			if c.Ports[j].Protocol == "" {
+				c.Ports[j].Protocol = corev1.ProtocolTCP
+			}
+		}
+		c.Env = DefaultEnv(c.Env)
+		for _, probe := range []*corev1.Probe{c.LivenessProbe, c.ReadinessProbe, c.StartupProbe} {
+			equality.DefaultProbe(probe)
+		}
+	}
+	return defaulted
+}
+
+// defaultPullPolicy returns the pull policy the API server defaults for
+// image, i.e. Always for untagged and "latest" images.
+func defaultPullPolicy(image string) corev1.PullPolicy {
+	_, ref := splitImage(image)
+	if strings.Contains(ref, "@") {
+		return corev1.PullIfNotPresent
+	}
+	if tag := strings.TrimPrefix(ref, ":"); tag == "" || tag == "latest" {
+		return corev1.PullAlways
+	}
+	return corev1.PullIfNotPresent
+}
+
+// ProxyEnv returns the environment variables configuring the HTTP proxy of
+// contour, or nil if no proxy is configured. The API server is always
+// excluded from proxying since in-cluster clients reach it directly.
+func ProxyEnv(contour *operatorv1alpha1.Contour) []corev1.EnvVar {
+	proxy := contour.Spec.ProxyConfig
+	if proxy == nil || (proxy.HTTPProxy == "" && proxy.HTTPSProxy == "") {
+		return nil
+	}
+	var env []corev1.EnvVar
+	if proxy.HTTPProxy != "" {
+		env = append(env, corev1.EnvVar{Name: "HTTP_PROXY", Value: proxy.HTTPProxy})
+	}
+	if proxy.HTTPSProxy != "" {
+		env = append(env, corev1.EnvVar{Name: "HTTPS_PROXY", Value: proxy.HTTPSProxy})
+	}
+	// Kubelet expands service environment variables such as
+	// KUBERNETES_SERVICE_HOST in the values of other variables.
+	noProxy := "$(KUBERNETES_SERVICE_HOST)"
+	if proxy.NoProxy != "" {
+		noProxy = proxy.NoProxy + "," + noProxy
+	}
+	return append(env, corev1.EnvVar{Name: "NO_PROXY", Value: noProxy})
+}
+
+// ApplyProbeSettings overrides the timing of probe with the fields set in
+// settings.
+func ApplyProbeSettings(probe *corev1.Probe, settings *operatorv1alpha1.ProbeSettings) {
+	if probe == nil || settings == nil {
+		return
+	}
+	if settings.InitialDelaySeconds != nil {
+		probe.InitialDelaySeconds = *settings.InitialDelaySeconds
+	}
+	if settings.TimeoutSeconds != nil {
+		probe.TimeoutSeconds = *settings.TimeoutSeconds
+	}
+	if settings.PeriodSeconds != nil {
+		probe.PeriodSeconds = *settings.PeriodSeconds
+	}
+	if settings.FailureThreshold != nil {
+		probe.FailureThreshold = *settings.FailureThreshold
 	}
-	return ""
 }
@@ -0,0 +1,286 @@
+// Copyright Project Contour Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package objects
+
+import (
+	"reflect"
+	"testing"
+
+	operatorv1alpha1 "github.com/projectcontour/contour-operator/api/v1alpha1"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestImageFor(t *testing.T) {
+	testCases := []struct {
+		description string
+		image       string
+		override    *operatorv1alpha1.ImageOverride
+		expected    string
+	}{
+		{
+			description: "no override",
+			image:       "ghcr.io/projectcontour/contour:main",
+			expected:    "ghcr.io/projectcontour/contour:main",
+		},
+		{
+			description: "tag override",
+			image:       "ghcr.io/projectcontour/contour:main",
+			override:    &operatorv1alpha1.ImageOverride{Tag: "v1.21.0"},
+			expected:    "ghcr.io/projectcontour/contour:v1.21.0",
+		},
+		{
+			description: "digest override",
+			image:       "ghcr.io/projectcontour/contour:main",
+			override:    &operatorv1alpha1.ImageOverride{Digest: "sha256:0123abcd"},
+			expected:    "ghcr.io/projectcontour/contour@sha256:0123abcd",
+		},
+		{
+			description: "repository override keeps the default tag",
+			image:       "docker.io/envoyproxy/envoy:v1.22.0",
+			override:    &operatorv1alpha1.ImageOverride{Repository: "registry.example.com/envoy"},
+			expected:    "registry.example.com/envoy:v1.22.0",
+		},
+		{
+			description: "registry port is not a tag",
+			image:       "localhost:5000/contour",
+			override:    &operatorv1alpha1.ImageOverride{Tag: "test"},
+			expected:    "localhost:5000/contour:test",
+		},
+	}
+
+	for _, tc := range testCases {
+		if actual := ImageFor(tc.image, tc.override); actual != tc.expected {
+			t.Errorf("%q: expected %q, got %q", tc.description, tc.expected, actual)
+		}
+	}
+}
+
+func TestTagFromImage(t *testing.T) {
+	testCases := map[string]string{
+		"ghcr.io/projectcontour/contour:main":                    "main",
+		"ghcr.io/projectcontour/contour":                         "",
+		"localhost:5000/contour:test":                            "test",
+		"ghcr.io/projectcontour/contour:v1.21.0@sha256:0123abc":  "v1.21.0",
+		"ghcr.io/projectcontour/contour@sha256:0123456789abcdef": "0123456789ab",
+	}
+
+	for image, expected := range testCases {
+		if actual := TagFromImage(image); actual != expected {
+			t.Errorf("%q: expected tag %q, got %q", image, expected, actual)
+		}
+	}
+}
+
+func TestMergePodMetadata(t *testing.T) {
+	meta := metav1.ObjectMeta{
+		Labels:      map[string]string{"app": "envoy"},
+		Annotations: map[string]string{"prometheus.io/scrape": "true"},
+	}
+	labels := map[string]string{"app": "other", "cost-center": "ingress"}
+	annotations := map[string]string{"prometheus.io/scrape": "false", "sidecar.istio.io/inject": "false"}
+
+	MergePodMetadata(&meta, labels, annotations)
+
+	expectedLabels := map[string]string{"app": "envoy", "cost-center": "ingress"}
+	if !reflect.DeepEqual(meta.Labels, expectedLabels) {
+		t.Errorf("expected labels %v, got %v", expectedLabels, meta.Labels)
+	}
+	if !reflect.DeepEqual(meta.Annotations, annotations) {
+		t.Errorf("expected annotations %v, got %v", annotations, meta.Annotations)
+	}
+}
+
+func TestTopologySpreadConstraints(t *testing.T) {
+	if constraints := TopologySpreadConstraints(nil, nil); constraints != nil {
+		t.Errorf("expected no constraints, got %v", constraints)
+	}
+
+	selector := &metav1.LabelSelector{MatchLabels: map[string]string{"app": "envoy"}}
+	custom := &metav1.LabelSelector{MatchLabels: map[string]string{"app": "other"}}
+	constraints := []corev1.TopologySpreadConstraint{
+		{
+			MaxSkew:           1,
+			TopologyKey:       "topology.kubernetes.io/zone",
+			WhenUnsatisfiable: corev1.ScheduleAnyway,
+		},
+		{
+			MaxSkew:           1,
+			TopologyKey:       "kubernetes.io/hostname",
+			WhenUnsatisfiable: corev1.DoNotSchedule,
+			LabelSelector:     custom,
+		},
+	}
+
+	defaulted := TopologySpreadConstraints(constraints, selector)
+	if !reflect.DeepEqual(defaulted[0].LabelSelector, selector) {
+		t.Errorf("expected label selector %v, got %v", selector, defaulted[0].LabelSelector)
+	}
+	if !reflect.DeepEqual(defaulted[1].LabelSelector, custom) {
+		t.Errorf("expected label selector %v, got %v", custom, defaulted[1].LabelSelector)
+	}
+	if constraints[0].LabelSelector != nil {
+		t.Errorf("expected constraints to be left unmodified")
+	}
+}
+
+func TestDefaultVolumes(t *testing.T) {
+	if volumes := DefaultVolumes(nil); volumes != nil {
+		t.Errorf("expected no volumes, got %v", volumes)
+	}
+
+	volumes := []corev1.Volume{
+		{
+			Name: "secret",
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{SecretName: "secret"},
+			},
+		},
+		{
+			Name: "host",
+			VolumeSource: corev1.VolumeSource{
+				HostPath: &corev1.HostPathVolumeSource{Path: "/var/log"},
+			},
+		},
+	}
+
+	defaulted := DefaultVolumes(volumes)
+	if mode := defaulted[0].Secret.DefaultMode; mode == nil || *mode != 420 {
+		t.Errorf("expected secret volume default mode 420, got %v", mode)
+	}
+	if typ := defaulted[1].HostPath.Type; typ == nil || *typ != corev1.HostPathUnset {
+		t.Errorf("expected host path volume type %q, got %v", corev1.HostPathUnset, typ)
+	}
+	if volumes[0].Secret.DefaultMode != nil || volumes[1].HostPath.Type != nil {
+		t.Errorf("expected volumes to be left unmodified")
+	}
+}
+
+func TestDefaultEnv(t *testing.T) {
+	if env := DefaultEnv(nil); env != nil {
+		t.Errorf("expected no environment variables, got %v", env)
+	}
+
+	env := []corev1.EnvVar{
+		{Name: "GOMAXPROCS", Value: "2"},
+		{
+			Name: "NODE_NAME",
+			ValueFrom: &corev1.EnvVarSource{
+				FieldRef: &corev1.ObjectFieldSelector{FieldPath: "spec.nodeName"},
+			},
+		},
+	}
+
+	defaulted := DefaultEnv(env)
+	if !reflect.DeepEqual(defaulted[0], env[0]) {
+		t.Errorf("expected environment variable %v, got %v", env[0], defaulted[0])
+	}
+	if version := defaulted[1].ValueFrom.FieldRef.APIVersion; version != "v1" {
+		t.Errorf("expected field ref API version %q, got %q", "v1", version)
+	}
+	if env[1].ValueFrom.FieldRef.APIVersion != "" {
+		t.Errorf("expected environment variables to be left unmodified")
+	}
+}
+
+func TestDefaultContainers(t *testing.T) {
+	if containers := DefaultContainers(nil); containers != nil {
+		t.Errorf("expected no containers, got %v", containers)
+	}
+
+	containers := []corev1.Container{
+		{
+			Name:  "fluent-bit",
+			Image: "fluent/fluent-bit",
+			Ports: []corev1.ContainerPort{{Name: "metrics", ContainerPort: 2020}},
+			LivenessProbe: &corev1.Probe{
+				ProbeHandler: corev1.ProbeHandler{
+					HTTPGet: &corev1.HTTPGetAction{Path: "/", Port: intstr.FromInt(2020)},
+				},
+			},
+		},
+		{
+			Name:  "agent",
+			Image: "example.com/agent:v1.0.0",
+		},
+	}
+
+	defaulted := DefaultContainers(containers)
+	if policy := defaulted[0].ImagePullPolicy; policy != corev1.PullAlways {
+		t.Errorf("expected pull policy %q, got %q", corev1.PullAlways, policy)
+	}
+	if policy := defaulted[1].ImagePullPolicy; policy != corev1.PullIfNotPresent {
+		t.Errorf("expected pull policy %q, got %q", corev1.PullIfNotPresent, policy)
+	}
+	if path := defaulted[1].TerminationMessagePath; path != corev1.TerminationMessagePathDefault {
+		t.Errorf("expected termination message path %q, got %q", corev1.TerminationMessagePathDefault, path)
+	}
+	if protocol := defaulted[0].Ports[0].Protocol; protocol != corev1.ProtocolTCP {
+		t.Errorf("expected port protocol %q, got %q", corev1.ProtocolTCP, protocol)
+	}
+	probe := defaulted[0].LivenessProbe
+	if probe.PeriodSeconds != 10 || probe.FailureThreshold != 3 || probe.HTTPGet.Scheme != corev1.URISchemeHTTP {
+		t.Errorf("expected defaulted liveness probe, got %v", probe)
+	}
+	if containers[0].ImagePullPolicy != "" || containers[0].LivenessProbe.PeriodSeconds != 0 {
+		t.Errorf("expected containers to be left unmodified")
+	}
+}
+
+func TestProxyEnv(t *testing.T) {
+	cntr := &operatorv1alpha1.Contour{}
+	if env := ProxyEnv(cntr); env != nil {
+		t.Errorf("expected no environment variables, got %v", env)
+	}
+
+	cntr.Spec.ProxyConfig = &operatorv1alpha1.ProxyConfig{
+		HTTPSProxy: "http://proxy.example.com:3128",
+		NoProxy:    ".svc,10.0.0.0/8",
+	}
+	expected := []corev1.EnvVar{
+		{Name: "HTTPS_PROXY", Value: "http://proxy.example.com:3128"},
+		{Name: "NO_PROXY", Value: ".svc,10.0.0.0/8,$(KUBERNETES_SERVICE_HOST)"},
+	}
+	if env := ProxyEnv(cntr); !reflect.DeepEqual(env, expected) {
+		t.Errorf("expected environment variables %v, got %v", expected, env)
+	}
+}
+
+func TestApplyProbeSettings(t *testing.T) {
+	probe := &corev1.Probe{
+		InitialDelaySeconds: 15,
+		TimeoutSeconds:      1,
+		PeriodSeconds:       10,
+		SuccessThreshold:    1,
+		FailureThreshold:    3,
+	}
+	ApplyProbeSettings(probe, nil)
+	ApplyProbeSettings(nil, &operatorv1alpha1.ProbeSettings{})
+
+	timeout := int32(5)
+	failures := int32(6)
+	ApplyProbeSettings(probe, &operatorv1alpha1.ProbeSettings{TimeoutSeconds: &timeout, FailureThreshold: &failures})
+	expected := &corev1.Probe{
+		InitialDelaySeconds: 15,
+		TimeoutSeconds:      5,
+		PeriodSeconds:       10,
+		SuccessThreshold:    1,
+		FailureThreshold:    6,
+	}
+	if !reflect.DeepEqual(probe, expected) {
+		t.Errorf("expected probe %v, got %v", expected, probe)
+	}
+}
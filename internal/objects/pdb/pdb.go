@@ -0,0 +1,189 @@
+// Copyright Project Contour Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pdb
+
+import (
+	"context"
+	"fmt"
+
+	operatorv1alpha1 "github.com/projectcontour/contour-operator/api/v1alpha1"
+	"github.com/projectcontour/contour-operator/internal/equality"
+	objcontour "github.com/projectcontour/contour-operator/internal/objects/contour"
+	objds "github.com/projectcontour/contour-operator/internal/objects/daemonset"
+	objdeploy "github.com/projectcontour/contour-operator/internal/objects/deployment"
+	"github.com/projectcontour/contour-operator/pkg/labels"
+
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// contourPDBName is the name of Contour's PodDisruptionBudget resource.
+	contourPDBName = "contour"
+	// envoyPDBName is the name of Envoy's PodDisruptionBudget resource.
+	envoyPDBName = objds.EnvoyDeploymentName
+)
+
+// defaultMaxUnavailable is the number of pods that may be unavailable
+// when neither minAvailable nor maxUnavailable is specified.
+var defaultMaxUnavailable = intstr.FromInt(1)
+
+// EnsureContourPDB ensures a PodDisruptionBudget exists for the Contour
+// Deployment of the given contour.
+func EnsureContourPDB(ctx context.Context, cli client.Client, contour *operatorv1alpha1.Contour) error {
+	return ensurePDB(ctx, cli, contour, DesiredContourPDB(contour))
+}
+
+// EnsureEnvoyPDB ensures a PodDisruptionBudget exists for the Envoy
+// Deployment of the given contour.
+func EnsureEnvoyPDB(ctx context.Context, cli client.Client, contour *operatorv1alpha1.Contour) error {
+	return ensurePDB(ctx, cli, contour, DesiredEnvoyPDB(contour))
+}
+
+// EnsureContourPDBDeleted ensures the PodDisruptionBudget for the Contour pods
+// of the provided contour is deleted if Contour owner labels exist.
+func EnsureContourPDBDeleted(ctx context.Context, cli client.Client, contour *operatorv1alpha1.Contour) error {
+	return ensurePDBDeleted(ctx, cli, contour, contourPDBName)
+}
+
+// EnsureEnvoyPDBDeleted ensures the PodDisruptionBudget for the Envoy pods
+// of the provided contour is deleted if Contour owner labels exist.
+func EnsureEnvoyPDBDeleted(ctx context.Context, cli client.Client, contour *operatorv1alpha1.Contour) error {
+	return ensurePDBDeleted(ctx, cli, contour, envoyPDBName)
+}
+
+// DesiredContourPDB returns the desired PodDisruptionBudget for the Contour
+// pods of the provided contour.
+func DesiredContourPDB(contour *operatorv1alpha1.Contour) *policyv1.PodDisruptionBudget {
+	return desiredPDB(contour, contourPDBName, objdeploy.ContourDeploymentPodSelector(),
+		contour.Spec.Contour.PodDisruptionBudget)
+}
+
+// DesiredEnvoyPDB returns the desired PodDisruptionBudget for the Envoy pods
+// of the provided contour.
+func DesiredEnvoyPDB(contour *operatorv1alpha1.Contour) *policyv1.PodDisruptionBudget {
+	return desiredPDB(contour, envoyPDBName, objds.EnvoyDaemonSetPodSelector(),
+		contour.Spec.Envoy.PodDisruptionBudget)
+}
+
+// desiredPDB returns a PodDisruptionBudget named name that protects the pods
+// matching selector according to settings.
+func desiredPDB(contour *operatorv1alpha1.Contour, name string, selector *metav1.LabelSelector,
+	settings *operatorv1alpha1.PodDisruptionBudgetSettings) *policyv1.PodDisruptionBudget {
+	labels := map[string]string{
+		"app.kubernetes.io/name":       "contour",
+		"app.kubernetes.io/instance":   contour.Name,
+		"app.kubernetes.io/component":  "ingress-controller",
+		"app.kubernetes.io/managed-by": "contour-operator",
+	}
+	// Add owner labels
+	for k, v := range objcontour.OwnerLabels(contour) {
+		labels[k] = v
+	}
+
+	pdb := &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: contour.Spec.Namespace.Name,
+			Name:      name,
+			Labels:    labels,
+		},
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			Selector:       selector,
+			MinAvailable:   settings.MinAvailable,
+			MaxUnavailable: settings.MaxUnavailable,
+		},
+	}
+	if pdb.Spec.MinAvailable == nil && pdb.Spec.MaxUnavailable == nil {
+		maxUnavailable := defaultMaxUnavailable
+		pdb.Spec.MaxUnavailable = &maxUnavailable
+	}
+	return pdb
+}
+
+// CurrentPDB returns the current PodDisruptionBudget named name for the
+// provided contour.
+func CurrentPDB(ctx context.Context, cli client.Client, contour *operatorv1alpha1.Contour, name string) (*policyv1.PodDisruptionBudget, error) {
+	pdb := &policyv1.PodDisruptionBudget{}
+	key := types.NamespacedName{
+		Namespace: contour.Spec.Namespace.Name,
+		Name:      name,
+	}
+	if err := cli.Get(ctx, key, pdb); err != nil {
+		return nil, err
+	}
+	return pdb, nil
+}
+
+// ensurePDB ensures desired exists for the provided contour.
+func ensurePDB(ctx context.Context, cli client.Client, contour *operatorv1alpha1.Contour, desired *policyv1.PodDisruptionBudget) error {
+	current, err := CurrentPDB(ctx, cli, contour, desired.Name)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return createPDB(ctx, cli, desired)
+		}
+		return fmt.Errorf("failed to get poddisruptionbudget %s/%s: %w", desired.Namespace, desired.Name, err)
+	}
+	if err := updatePDBIfNeeded(ctx, cli, contour, current, desired); err != nil {
+		return fmt.Errorf("failed to update poddisruptionbudget for contour %s/%s: %w", contour.Namespace, contour.Name, err)
+	}
+	return nil
+}
+
+// ensurePDBDeleted ensures the PodDisruptionBudget named name is deleted if
+// Contour owner labels exist.
+func ensurePDBDeleted(ctx context.Context, cli client.Client, contour *operatorv1alpha1.Contour, name string) error {
+	pdb, err := CurrentPDB(ctx, cli, contour, name)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if labels.Exist(pdb, objcontour.OwnerLabels(contour)) {
+		if err := cli.Delete(ctx, pdb); err != nil {
+			if errors.IsNotFound(err) {
+				return nil
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// createPDB creates a PodDisruptionBudget resource for the provided pdb.
+func createPDB(ctx context.Context, cli client.Client, pdb *policyv1.PodDisruptionBudget) error {
+	if err := cli.Create(ctx, pdb); err != nil {
+		return fmt.Errorf("failed to create poddisruptionbudget %s/%s: %w", pdb.Namespace, pdb.Name, err)
+	}
+	return nil
+}
+
+// updatePDBIfNeeded updates a PodDisruptionBudget if current does not match
+// desired, using contour to verify the existence of owner labels.
+func updatePDBIfNeeded(ctx context.Context, cli client.Client, contour *operatorv1alpha1.Contour, current, desired *policyv1.PodDisruptionBudget) error {
+	if labels.Exist(current, objcontour.OwnerLabels(contour)) {
+		pdb, updated := equality.PodDisruptionBudgetChanged(current, desired)
+		if updated {
+			if err := cli.Update(ctx, pdb); err != nil {
+				return fmt.Errorf("failed to update poddisruptionbudget %s/%s: %w", pdb.Namespace, pdb.Name, err)
+			}
+			return nil
+		}
+	}
+	return nil
+}
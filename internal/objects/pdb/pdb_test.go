@@ -0,0 +1,79 @@
+// Copyright Project Contour Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pdb
+
+import (
+	"fmt"
+	"testing"
+
+	operatorv1alpha1 "github.com/projectcontour/contour-operator/api/v1alpha1"
+	objcontour "github.com/projectcontour/contour-operator/internal/objects/contour"
+
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func checkPDBHasSelectorLabel(t *testing.T, pdb *policyv1.PodDisruptionBudget, key, value string) {
+	t.Helper()
+
+	if pdb.Spec.Selector == nil || pdb.Spec.Selector.MatchLabels[key] != value {
+		t.Errorf("pdb %s has selector %v; expected %s=%s", pdb.Name, pdb.Spec.Selector, key, value)
+	}
+}
+
+func TestDesiredPDBs(t *testing.T) {
+	name := "pdb-test"
+	cfg := objcontour.Config{
+		Name:        name,
+		Namespace:   fmt.Sprintf("%s-ns", name),
+		SpecNs:      "projectcontour",
+		RemoveNs:    false,
+		NetworkType: operatorv1alpha1.LoadBalancerServicePublishingType,
+	}
+	cntr := objcontour.New(cfg)
+	minAvailable := intstr.FromString("50%")
+	cntr.Spec.Contour = &operatorv1alpha1.ContourSettings{
+		PodDisruptionBudget: &operatorv1alpha1.PodDisruptionBudgetSettings{},
+	}
+	cntr.Spec.Envoy = &operatorv1alpha1.EnvoySettings{
+		WorkloadType: operatorv1alpha1.DeploymentEnvoyWorkload,
+		PodDisruptionBudget: &operatorv1alpha1.PodDisruptionBudgetSettings{
+			MinAvailable: &minAvailable,
+		},
+	}
+
+	pdb := DesiredContourPDB(cntr)
+	if pdb.Namespace != cfg.SpecNs || pdb.Name != contourPDBName {
+		t.Errorf("pdb is %s/%s; expected %s/%s", pdb.Namespace, pdb.Name, cfg.SpecNs, contourPDBName)
+	}
+	checkPDBHasSelectorLabel(t, pdb, "app", "contour")
+	if pdb.Spec.MinAvailable != nil {
+		t.Errorf("pdb %s has min available %s; expected none", pdb.Name, pdb.Spec.MinAvailable.String())
+	}
+	if pdb.Spec.MaxUnavailable == nil || *pdb.Spec.MaxUnavailable != defaultMaxUnavailable {
+		t.Errorf("pdb %s has max unavailable %v; expected %s", pdb.Name, pdb.Spec.MaxUnavailable, defaultMaxUnavailable.String())
+	}
+
+	pdb = DesiredEnvoyPDB(cntr)
+	if pdb.Namespace != cfg.SpecNs || pdb.Name != envoyPDBName {
+		t.Errorf("pdb is %s/%s; expected %s/%s", pdb.Namespace, pdb.Name, cfg.SpecNs, envoyPDBName)
+	}
+	checkPDBHasSelectorLabel(t, pdb, "app", "envoy")
+	if pdb.Spec.MaxUnavailable != nil {
+		t.Errorf("pdb %s has max unavailable %s; expected none", pdb.Name, pdb.Spec.MaxUnavailable.String())
+	}
+	if pdb.Spec.MinAvailable == nil || *pdb.Spec.MinAvailable != minAvailable {
+		t.Errorf("pdb %s has min available %v; expected %s", pdb.Name, pdb.Spec.MinAvailable, minAvailable.String())
+	}
+}
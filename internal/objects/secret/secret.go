@@ -0,0 +1,166 @@
+// Copyright Project Contour Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secret
+
+import (
+	"context"
+	"fmt"
+
+	operatorv1alpha1 "github.com/projectcontour/contour-operator/api/v1alpha1"
+	"github.com/projectcontour/contour-operator/internal/equality"
+	objcontour "github.com/projectcontour/contour-operator/internal/objects/contour"
+	"github.com/projectcontour/contour-operator/pkg/labels"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// MirroredSecretLabel is the label identifying secrets mirrored by the
+	// operator into the namespace of a contour.
+	MirroredSecretLabel = "contour.operator.projectcontour.io/mirrored-secret"
+)
+
+// EnsureImagePullSecrets ensures the image pull secrets of the given contour
+// marked for mirroring exist in spec.namespace.name and match their source
+// in namespace ns, and removes mirrored secrets that are no longer referenced.
+func EnsureImagePullSecrets(ctx context.Context, cli client.Client, contour *operatorv1alpha1.Contour, ns string) error {
+	var errs []error
+	mirrored := map[string]bool{}
+	for _, ref := range contour.Spec.ImagePullSecretRefs {
+		if !ref.Mirror {
+			continue
+		}
+		mirrored[ref.Name] = true
+		if err := ensureMirroredSecret(ctx, cli, contour, ns, ref.Name); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	current, err := currentMirroredSecrets(ctx, cli, contour)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("failed to list mirrored secrets in namespace %s: %w", contour.Spec.Namespace.Name, err))
+	}
+	for i := range current {
+		if mirrored[current[i].Name] {
+			continue
+		}
+		if err := deleteSecret(ctx, cli, &current[i]); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return utilerrors.NewAggregate(errs)
+}
+
+// EnsureImagePullSecretsDeleted ensures the image pull secrets mirrored for
+// the provided contour are deleted.
+func EnsureImagePullSecretsDeleted(ctx context.Context, cli client.Client, contour *operatorv1alpha1.Contour) error {
+	current, err := currentMirroredSecrets(ctx, cli, contour)
+	if err != nil {
+		return err
+	}
+	for i := range current {
+		if err := deleteSecret(ctx, cli, &current[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DesiredMirroredSecret returns the desired copy of source in spec.namespace.name
+// of the provided contour.
+func DesiredMirroredSecret(contour *operatorv1alpha1.Contour, source *corev1.Secret) *corev1.Secret {
+	labels := map[string]string{
+		"app.kubernetes.io/name":       "contour",
+		"app.kubernetes.io/instance":   contour.Name,
+		"app.kubernetes.io/component":  "ingress-controller",
+		"app.kubernetes.io/managed-by": "contour-operator",
+		MirroredSecretLabel:            "true",
+	}
+	// Add owner labels
+	for k, v := range objcontour.OwnerLabels(contour) {
+		labels[k] = v
+	}
+
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: contour.Spec.Namespace.Name,
+			Name:      source.Name,
+			Labels:    labels,
+		},
+		Type: source.Type,
+		Data: source.Data,
+	}
+}
+
+// ensureMirroredSecret ensures the secret named name in namespace ns is mirrored
+// into spec.namespace.name of contour.
+func ensureMirroredSecret(ctx context.Context, cli client.Client, contour *operatorv1alpha1.Contour, ns, name string) error {
+	// The secret is already in place when it resides in spec.namespace.name.
+	if ns == contour.Spec.Namespace.Name {
+		return nil
+	}
+	source := &corev1.Secret{}
+	key := types.NamespacedName{Namespace: ns, Name: name}
+	if err := cli.Get(ctx, key, source); err != nil {
+		return fmt.Errorf("failed to get secret %s/%s to mirror: %w", key.Namespace, key.Name, err)
+	}
+
+	desired := DesiredMirroredSecret(contour, source)
+	current := &corev1.Secret{}
+	key = types.NamespacedName{Namespace: desired.Namespace, Name: desired.Name}
+	if err := cli.Get(ctx, key, current); err != nil {
+		if errors.IsNotFound(err) {
+			if err := cli.Create(ctx, desired); err != nil {
+				return fmt.Errorf("failed to create secret %s/%s: %w", desired.Namespace, desired.Name, err)
+			}
+			return nil
+		}
+		return fmt.Errorf("failed to get secret %s/%s: %w", key.Namespace, key.Name, err)
+	}
+	if labels.Exist(current, objcontour.OwnerLabels(contour)) {
+		if secret, changed := equality.SecretChanged(current, desired); changed {
+			if err := cli.Update(ctx, secret); err != nil {
+				return fmt.Errorf("failed to update secret %s/%s: %w", secret.Namespace, secret.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// currentMirroredSecrets returns the secrets mirrored for the provided contour.
+func currentMirroredSecrets(ctx context.Context, cli client.Client, contour *operatorv1alpha1.Contour) ([]corev1.Secret, error) {
+	matching := map[string]string{MirroredSecretLabel: "true"}
+	for k, v := range objcontour.OwnerLabels(contour) {
+		matching[k] = v
+	}
+	secrets := &corev1.SecretList{}
+	if err := cli.List(ctx, secrets, client.InNamespace(contour.Spec.Namespace.Name), client.MatchingLabels(matching)); err != nil {
+		return nil, err
+	}
+	return secrets.Items, nil
+}
+
+// deleteSecret deletes the provided secret, ignoring secrets that don't exist.
+func deleteSecret(ctx context.Context, cli client.Client, secret *corev1.Secret) error {
+	if err := cli.Delete(ctx, secret); err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete secret %s/%s: %w", secret.Namespace, secret.Name, err)
+	}
+	return nil
+}
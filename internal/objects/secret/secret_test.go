@@ -0,0 +1,68 @@
+// Copyright Project Contour Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secret
+
+import (
+	"fmt"
+	"testing"
+
+	operatorv1alpha1 "github.com/projectcontour/contour-operator/api/v1alpha1"
+	objcontour "github.com/projectcontour/contour-operator/internal/objects/contour"
+	"github.com/projectcontour/contour-operator/pkg/labels"
+
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestDesiredMirroredSecret(t *testing.T) {
+	name := "secret-test"
+	cfg := objcontour.Config{
+		Name:        name,
+		Namespace:   fmt.Sprintf("%s-ns", name),
+		SpecNs:      "projectcontour",
+		RemoveNs:    false,
+		NetworkType: operatorv1alpha1.LoadBalancerServicePublishingType,
+	}
+	cntr := objcontour.New(cfg)
+	source := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: cfg.Namespace,
+			Name:      "registry-creds",
+			Labels:    map[string]string{"team": "platform"},
+		},
+		Type: corev1.SecretTypeDockerConfigJson,
+		Data: map[string][]byte{corev1.DockerConfigJsonKey: []byte("{}")},
+	}
+
+	secret := DesiredMirroredSecret(cntr, source)
+	if secret.Namespace != cfg.SpecNs || secret.Name != source.Name {
+		t.Errorf("secret is %s/%s; expected %s/%s", secret.Namespace, secret.Name, cfg.SpecNs, source.Name)
+	}
+	if secret.Type != source.Type {
+		t.Errorf("secret has type %q; expected %q", secret.Type, source.Type)
+	}
+	if !apiequality.Semantic.DeepEqual(secret.Data, source.Data) {
+		t.Errorf("secret has unexpected data")
+	}
+	if !labels.Exist(secret, objcontour.OwnerLabels(cntr)) {
+		t.Errorf("secret is missing owner labels")
+	}
+	if secret.Labels[MirroredSecretLabel] != "true" {
+		t.Errorf("secret is missing label %s", MirroredSecretLabel)
+	}
+	if _, found := secret.Labels["team"]; found {
+		t.Errorf("secret has source labels")
+	}
+}
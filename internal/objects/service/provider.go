@@ -0,0 +1,211 @@
+// Copyright Project Contour Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	operatorv1alpha1 "github.com/projectcontour/contour-operator/api/v1alpha1"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// loadBalancerProvider configures the Envoy Service for the infrastructure
+// provider of a load balancer.
+type loadBalancerProvider interface {
+	// configureService applies the provider-specific settings of contour to svc.
+	configureService(contour *operatorv1alpha1.Contour, svc *corev1.Service)
+}
+
+// loadBalancerProviders maps a load balancer provider type to its implementation.
+var loadBalancerProviders = map[operatorv1alpha1.LoadBalancerProviderType]loadBalancerProvider{
+	operatorv1alpha1.AWSLoadBalancerProvider:     awsProvider{},
+	operatorv1alpha1.AzureLoadBalancerProvider:   azureProvider{},
+	operatorv1alpha1.GCPLoadBalancerProvider:     gcpProvider{},
+	operatorv1alpha1.MetalLBLoadBalancerProvider: metalLBProvider{},
+}
+
+// awsProvider configures the Envoy Service for AWS load balancers.
+type awsProvider struct{}
+
+func (awsProvider) configureService(contour *operatorv1alpha1.Contour, svc *corev1.Service) {
+	params := &contour.Spec.NetworkPublishing.Envoy.LoadBalancer.ProviderParameters
+	// Add the TCP backend protocol annotation for AWS classic load balancers.
+	if isELB(params) {
+		svc.Annotations[awsLbBackendProtoAnnotation] = "tcp"
+	} else {
+		// Annotate the service for an NLB.
+		svc.Annotations[awsLBTypeAnnotation] = "nlb"
+	}
+	if contour.ProxyProtocolEnabled() {
+		svc.Annotations[awsLBProxyProtocolAnnotation] = "*"
+	}
+
+	// Add the AllocationIDs annotation if specified by AWS provider parameters.
+	if allocationIDsNeeded(&contour.Spec) {
+		svc.Annotations[awsLBAllocationIDsAnnotation] = strings.Join(params.AWS.AllocationIDs, ",")
+	}
+}
+
+// azureProvider configures the Envoy Service for Azure load balancers.
+type azureProvider struct{}
+
+func (azureProvider) configureService(contour *operatorv1alpha1.Contour, svc *corev1.Service) {
+	params := &contour.Spec.NetworkPublishing.Envoy.LoadBalancer.ProviderParameters
+	// Add the ResourceGroup annotation if specified by Azure provider parameters.
+	if resourceGroupNeeded(&contour.Spec) {
+		svc.Annotations[azureLBResourceGroupAnnotation] = *params.Azure.ResourceGroup
+	}
+
+	// Add the public IP name annotation if specified by Azure provider parameters.
+	if publicIPNameNeeded(&contour.Spec) {
+		svc.Annotations[azurePIPNameAnnotation] = *params.Azure.PublicIPName
+	}
+
+	// Add the Subnet annotation if specified by Azure provider parameters.
+	if subnetNeeded(&contour.Spec) {
+		svc.Annotations[azureLBSubnetAnnotation] = *params.Azure.Subnet
+	}
+
+	// Add LoadBalancerIP parameter if specified by Azure provider parameters.
+	if loadBalancerAddressNeeded(&contour.Spec) {
+		svc.Spec.LoadBalancerIP = *params.Azure.Address
+	}
+}
+
+// gcpProvider configures the Envoy Service for GCP load balancers.
+type gcpProvider struct{}
+
+func (gcpProvider) configureService(contour *operatorv1alpha1.Contour, svc *corev1.Service) {
+	params := &contour.Spec.NetworkPublishing.Envoy.LoadBalancer.ProviderParameters
+	// Add the Subnet annotation if specified by GCP provider parameters.
+	if subnetNeeded(&contour.Spec) {
+		svc.Annotations[gcpLBSubnetAnnotation] = *params.GCP.Subnet
+	}
+
+	// Add LoadBalancerIP parameter if specified by GCP provider parameters.
+	if loadBalancerAddressNeeded(&contour.Spec) {
+		svc.Spec.LoadBalancerIP = *params.GCP.Address
+	}
+
+	// Add the NEG annotation if specified by GCP provider parameters.
+	if params.GCP != nil && params.GCP.NetworkEndpointGroups != nil {
+		if neg := negAnnotationValue(params.GCP.NetworkEndpointGroups); neg != "" {
+			svc.Annotations[gcpNEGAnnotation] = neg
+		}
+	}
+}
+
+// negAnnotationValue returns the "cloud.google.com/neg" annotation value for negs,
+// or an empty string if no NEGs are requested.
+func negAnnotationValue(negs *operatorv1alpha1.GCPNetworkEndpointGroups) string {
+	if !negs.Ingress && len(negs.ExposedPorts) == 0 {
+		return ""
+	}
+	value := struct {
+		Ingress      bool                `json:"ingress,omitempty"`
+		ExposedPorts map[string]struct{} `json:"exposed_ports,omitempty"`
+	}{Ingress: negs.Ingress}
+	for _, port := range negs.ExposedPorts {
+		if value.ExposedPorts == nil {
+			value.ExposedPorts = map[string]struct{}{}
+		}
+		value.ExposedPorts[strconv.Itoa(int(port))] = struct{}{}
+	}
+	// Marshaling can't fail for these types, and map keys are sorted so the
+	// value is stable across reconciles.
+	b, _ := json.Marshal(value)
+	return string(b)
+}
+
+// metalLBProvider configures the Envoy Service for MetalLB load balancers.
+type metalLBProvider struct{}
+
+func (metalLBProvider) configureService(contour *operatorv1alpha1.Contour, svc *corev1.Service) {
+	params := contour.Spec.NetworkPublishing.Envoy.LoadBalancer.ProviderParameters.MetalLB
+	if params == nil {
+		return
+	}
+	if params.AddressPool != nil {
+		svc.Annotations[metalLBAddressPoolAnnotation] = *params.AddressPool
+	}
+	if params.AllowSharedIP != nil {
+		svc.Annotations[metalLBAllowSharedIPAnnotation] = *params.AllowSharedIP
+	}
+}
+
+// isELB returns true if params is an AWS Classic ELB.
+func isELB(params *operatorv1alpha1.ProviderLoadBalancerParameters) bool {
+	return params.Type == operatorv1alpha1.AWSLoadBalancerProvider &&
+		(params.AWS == nil || params.AWS.Type == operatorv1alpha1.AWSClassicLoadBalancer)
+}
+
+// allocationIDsNeeded returns true if "service.beta.kubernetes.io/aws-load-balancer-eip-allocations"
+// annotation is needed based on the provided spec.
+func allocationIDsNeeded(spec *operatorv1alpha1.ContourSpec) bool {
+	return spec.NetworkPublishing.Envoy.Type == operatorv1alpha1.LoadBalancerServicePublishingType &&
+		spec.NetworkPublishing.Envoy.LoadBalancer.Scope == "External" &&
+		spec.NetworkPublishing.Envoy.LoadBalancer.ProviderParameters.Type == operatorv1alpha1.AWSLoadBalancerProvider &&
+		spec.NetworkPublishing.Envoy.LoadBalancer.ProviderParameters.AWS != nil &&
+		spec.NetworkPublishing.Envoy.LoadBalancer.ProviderParameters.AWS.Type == operatorv1alpha1.AWSNetworkLoadBalancer &&
+		spec.NetworkPublishing.Envoy.LoadBalancer.ProviderParameters.AWS.AllocationIDs != nil
+}
+
+// resourceGroupNeeded returns true if "service.beta.kubernetes.io/azure-load-balancer-resource-group"
+// annotation is needed based on the provided spec.
+func resourceGroupNeeded(spec *operatorv1alpha1.ContourSpec) bool {
+	return spec.NetworkPublishing.Envoy.Type == operatorv1alpha1.LoadBalancerServicePublishingType &&
+		spec.NetworkPublishing.Envoy.LoadBalancer.ProviderParameters.Type == operatorv1alpha1.AzureLoadBalancerProvider &&
+		spec.NetworkPublishing.Envoy.LoadBalancer.ProviderParameters.Azure != nil &&
+		spec.NetworkPublishing.Envoy.LoadBalancer.Scope == "External" &&
+		spec.NetworkPublishing.Envoy.LoadBalancer.ProviderParameters.Azure.ResourceGroup != nil
+}
+
+// publicIPNameNeeded returns true if "service.beta.kubernetes.io/azure-pip-name"
+// annotation is needed based on the provided spec.
+func publicIPNameNeeded(spec *operatorv1alpha1.ContourSpec) bool {
+	return spec.NetworkPublishing.Envoy.Type == operatorv1alpha1.LoadBalancerServicePublishingType &&
+		spec.NetworkPublishing.Envoy.LoadBalancer.ProviderParameters.Type == operatorv1alpha1.AzureLoadBalancerProvider &&
+		spec.NetworkPublishing.Envoy.LoadBalancer.ProviderParameters.Azure != nil &&
+		spec.NetworkPublishing.Envoy.LoadBalancer.Scope == "External" &&
+		spec.NetworkPublishing.Envoy.LoadBalancer.ProviderParameters.Azure.PublicIPName != nil
+}
+
+// subnetNeeded returns true if "service.beta.kubernetes.io/azure-load-balancer-internal-subnet" or
+// "networking.gke.io/internal-load-balancer-subnet" annotation is needed based
+// on the provided spec.
+func subnetNeeded(spec *operatorv1alpha1.ContourSpec) bool {
+	return spec.NetworkPublishing.Envoy.Type == operatorv1alpha1.LoadBalancerServicePublishingType &&
+		spec.NetworkPublishing.Envoy.LoadBalancer.Scope == "Internal" &&
+		((spec.NetworkPublishing.Envoy.LoadBalancer.ProviderParameters.Type == operatorv1alpha1.AzureLoadBalancerProvider &&
+			spec.NetworkPublishing.Envoy.LoadBalancer.ProviderParameters.Azure != nil &&
+			spec.NetworkPublishing.Envoy.LoadBalancer.ProviderParameters.Azure.Subnet != nil) ||
+			(spec.NetworkPublishing.Envoy.LoadBalancer.ProviderParameters.Type == operatorv1alpha1.GCPLoadBalancerProvider &&
+				spec.NetworkPublishing.Envoy.LoadBalancer.ProviderParameters.GCP != nil &&
+				spec.NetworkPublishing.Envoy.LoadBalancer.ProviderParameters.GCP.Subnet != nil))
+}
+
+// loadBalancerAddressNeeded returns true if LoadBalancerIP parameter of service
+// is needed based on provided spec.
+func loadBalancerAddressNeeded(spec *operatorv1alpha1.ContourSpec) bool {
+	return spec.NetworkPublishing.Envoy.Type == operatorv1alpha1.LoadBalancerServicePublishingType &&
+		((spec.NetworkPublishing.Envoy.LoadBalancer.ProviderParameters.Type == operatorv1alpha1.AzureLoadBalancerProvider &&
+			spec.NetworkPublishing.Envoy.LoadBalancer.ProviderParameters.Azure != nil &&
+			spec.NetworkPublishing.Envoy.LoadBalancer.ProviderParameters.Azure.Address != nil) ||
+			(spec.NetworkPublishing.Envoy.LoadBalancer.ProviderParameters.Type == operatorv1alpha1.GCPLoadBalancerProvider &&
+				spec.NetworkPublishing.Envoy.LoadBalancer.ProviderParameters.GCP != nil &&
+				spec.NetworkPublishing.Envoy.LoadBalancer.ProviderParameters.GCP.Address != nil))
+}
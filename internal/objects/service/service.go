@@ -16,7 +16,6 @@ package service
 import (
 	"context"
 	"fmt"
-	"strings"
 
 	operatorv1alpha1 "github.com/projectcontour/contour-operator/api/v1alpha1"
 	"github.com/projectcontour/contour-operator/internal/equality"
@@ -31,6 +30,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -43,18 +43,20 @@ const (
 	// Contours/ns when https://github.com/projectcontour/contour/issues/2122 is fixed.
 	// envoySvcName is the name of Envoy's Service.
 	envoySvcName = "envoy"
+	// contourMetricsSvcName is the name of the Service exposing Contour's metrics.
+	contourMetricsSvcName = "contour-metrics"
+	// envoyMetricsSvcName is the name of the Service exposing Envoy's metrics.
+	envoyMetricsSvcName = "envoy-metrics"
 	// awsLbBackendProtoAnnotation is a Service annotation that places the AWS ELB into
 	// "TCP" mode so that it does not do HTTP negotiation for HTTPS connections at the
 	// ELB edge. The downside of this is the remote IP address of all connections will
-	// appear to be the internal address of the ELB.
-	// TODO [danehans]: Make proxy protocol configurable or automatically enabled. See
-	// https://github.com/projectcontour/contour-operator/issues/49 for details.
+	// appear to be the internal address of the ELB unless the PROXY protocol is enabled.
 	awsLbBackendProtoAnnotation = "service.beta.kubernetes.io/aws-load-balancer-backend-protocol"
 	// awsLBTypeAnnotation is a Service annotation used to specify an AWS load
 	// balancer type. See the following for additional details:
 	// https://kubernetes.io/docs/concepts/services-networking/service/#aws-nlb-support
 	awsLBTypeAnnotation = "service.beta.kubernetes.io/aws-load-balancer-type"
-	// awsLBProxyProtocolAnnotation is used to enable the PROXY protocol for an AWS Classic
+	// awsLBProxyProtocolAnnotation is used to enable the PROXY protocol for an AWS
 	// load balancer. For additional details, see:
 	// https://kubernetes.io/docs/concepts/services-networking/service/#proxy-protocol-support-on-aws
 	awsLBProxyProtocolAnnotation = "service.beta.kubernetes.io/aws-load-balancer-proxy-protocol"
@@ -69,6 +71,12 @@ const (
 	// to assign Load Balancer IP based on Public IP Azure resource that resides in
 	// different resource group as AKS cluster when load balancer scope is set to "External".
 	azureLBResourceGroupAnnotation = "service.beta.kubernetes.io/azure-load-balancer-resource-group"
+	// azurePIPNameAnnotation is a Service annotation that provides capability to assign
+	// an existing public IP resource, referenced by name, to an Azure load balancer.
+	azurePIPNameAnnotation = "service.beta.kubernetes.io/azure-pip-name"
+	// externalDNSHostnameAnnotation is a Service annotation used by external-dns to
+	// publish DNS records for the Service's load balancer.
+	externalDNSHostnameAnnotation = "external-dns.alpha.kubernetes.io/hostname"
 	// azureLBSubnetAnnotation is a Service annotation that provides capability to assign
 	// Load Balancer IP based on desired subnet when load balancer scope is set to "Internal".
 	azureLBSubnetAnnotation = "service.beta.kubernetes.io/azure-load-balancer-internal-subnet"
@@ -84,6 +92,16 @@ const (
 	// gcpLBTypeAnnotation is the annotation used on a service to specify a GCP load balancer
 	// type for GKE version 1.17 and later.
 	gcpLBTypeAnnotation = "networking.gke.io/load-balancer-type"
+	// gcpNEGAnnotation is a Service annotation used to create GKE network endpoint
+	// groups for container-native load balancing. See the following for details:
+	// https://cloud.google.com/kubernetes-engine/docs/how-to/standalone-neg
+	gcpNEGAnnotation = "cloud.google.com/neg"
+	// metalLBAddressPoolAnnotation is a Service annotation used to request a
+	// load balancer address from a specific MetalLB address pool.
+	metalLBAddressPoolAnnotation = "metallb.universe.tf/address-pool"
+	// metalLBAllowSharedIPAnnotation is a Service annotation used to share a
+	// MetalLB load balancer address between Services with the same sharing key.
+	metalLBAllowSharedIPAnnotation = "metallb.universe.tf/allow-shared-ip"
 	// EnvoyServiceHTTPPort is the HTTP port number of the Envoy service.
 	EnvoyServiceHTTPPort = int32(80)
 	// EnvoyServiceHTTPSPort is the HTTPS port number of the Envoy service.
@@ -136,7 +154,7 @@ func EnsureContourService(ctx context.Context, cli client.Client, contour *opera
 // EnsureEnvoyService ensures that an Envoy Service exists for the given contour.
 func EnsureEnvoyService(ctx context.Context, cli client.Client, contour *operatorv1alpha1.Contour) error {
 	desired := DesiredEnvoyService(contour)
-	current, err := currentEnvoyService(ctx, cli, contour)
+	current, err := CurrentEnvoyService(ctx, cli, contour)
 	if err != nil {
 		if errors.IsNotFound(err) {
 			return createService(ctx, cli, desired)
@@ -173,7 +191,7 @@ func EnsureContourServiceDeleted(ctx context.Context, cli client.Client, contour
 // EnsureEnvoyServiceDeleted ensures that an Envoy Service for the
 // provided contour is deleted.
 func EnsureEnvoyServiceDeleted(ctx context.Context, cli client.Client, contour *operatorv1alpha1.Contour) error {
-	svc, err := currentEnvoyService(ctx, cli, contour)
+	svc, err := CurrentEnvoyService(ctx, cli, contour)
 	if err != nil {
 		if errors.IsNotFound(err) {
 			return nil
@@ -191,6 +209,127 @@ func EnsureEnvoyServiceDeleted(ctx context.Context, cli client.Client, contour *
 	return nil
 }
 
+// EnsureContourMetricsService ensures that a Service exposing Contour's metrics
+// exists for the given contour.
+func EnsureContourMetricsService(ctx context.Context, cli client.Client, contour *operatorv1alpha1.Contour) error {
+	return ensureMetricsService(ctx, cli, contour, DesiredContourMetricsService(contour))
+}
+
+// EnsureEnvoyMetricsService ensures that a Service exposing Envoy's metrics
+// exists for the given contour.
+func EnsureEnvoyMetricsService(ctx context.Context, cli client.Client, contour *operatorv1alpha1.Contour) error {
+	return ensureMetricsService(ctx, cli, contour, DesiredEnvoyMetricsService(contour))
+}
+
+// EnsureContourMetricsServiceDeleted ensures that the Service exposing Contour's
+// metrics for the provided contour is deleted if Contour owner labels exist.
+func EnsureContourMetricsServiceDeleted(ctx context.Context, cli client.Client, contour *operatorv1alpha1.Contour) error {
+	return ensureServiceDeleted(ctx, cli, contour, contourMetricsSvcName)
+}
+
+// EnsureEnvoyMetricsServiceDeleted ensures that the Service exposing Envoy's
+// metrics for the provided contour is deleted if Contour owner labels exist.
+func EnsureEnvoyMetricsServiceDeleted(ctx context.Context, cli client.Client, contour *operatorv1alpha1.Contour) error {
+	return ensureServiceDeleted(ctx, cli, contour, envoyMetricsSvcName)
+}
+
+// ensureMetricsService ensures that the desired metrics Service exists for contour.
+func ensureMetricsService(ctx context.Context, cli client.Client, contour *operatorv1alpha1.Contour, desired *corev1.Service) error {
+	current, err := currentService(ctx, cli, desired.Namespace, desired.Name)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return createService(ctx, cli, desired)
+		}
+		return fmt.Errorf("failed to get service %s/%s: %w", desired.Namespace, desired.Name, err)
+	}
+	if labels.Exist(current, objcontour.OwnerLabels(contour)) {
+		if updated, needed := equality.MetricsServiceChanged(current, desired); needed {
+			if err := cli.Update(ctx, updated); err != nil {
+				return fmt.Errorf("failed to update service %s/%s: %w", desired.Namespace, desired.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// ensureServiceDeleted deletes the Service named name for the provided contour
+// if Contour owner labels exist.
+func ensureServiceDeleted(ctx context.Context, cli client.Client, contour *operatorv1alpha1.Contour, name string) error {
+	svc, err := currentService(ctx, cli, contour.Spec.Namespace.Name, name)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if labels.Exist(svc, objcontour.OwnerLabels(contour)) {
+		if err := cli.Delete(ctx, svc); err != nil {
+			if errors.IsNotFound(err) {
+				return nil
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// DesiredContourMetricsService generates the desired Service exposing Contour's
+// metrics for the given contour.
+func DesiredContourMetricsService(contour *operatorv1alpha1.Contour) *corev1.Service {
+	return desiredMetricsService(contour, contourMetricsSvcName, "contour", objcfg.ContourMetricsPort,
+		"/metrics", objdeploy.ContourDeploymentPodSelector().MatchLabels)
+}
+
+// DesiredEnvoyMetricsService generates the desired Service exposing Envoy's
+// metrics for the given contour.
+func DesiredEnvoyMetricsService(contour *operatorv1alpha1.Contour) *corev1.Service {
+	return desiredMetricsService(contour, envoyMetricsSvcName, "envoy", objcfg.EnvoyMetricsPort,
+		"/stats/prometheus", objds.EnvoyDaemonSetPodSelector().MatchLabels)
+}
+
+// desiredMetricsService generates a ClusterIP Service named name that exposes
+// the metrics port of the pods matching selector. The Service is labeled with
+// app so it can be selected for scraping, e.g. by a Prometheus ServiceMonitor.
+func desiredMetricsService(contour *operatorv1alpha1.Contour, name, app string, port int32, path string, selector map[string]string) *corev1.Service {
+	svcLabels := map[string]string{
+		"app.kubernetes.io/name":       app,
+		"app.kubernetes.io/instance":   contour.Name,
+		"app.kubernetes.io/component":  "metrics",
+		"app.kubernetes.io/managed-by": "contour-operator",
+	}
+	for k, v := range objcontour.OwnerLabels(contour) {
+		svcLabels[k] = v
+	}
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: contour.Spec.Namespace.Name,
+			Name:      name,
+			Labels:    svcLabels,
+			Annotations: map[string]string{
+				"prometheus.io/scrape": "true",
+				"prometheus.io/port":   fmt.Sprintf("%d", port),
+				"prometheus.io/path":   path,
+			},
+		},
+		Spec: corev1.ServiceSpec{
+			Ports: []corev1.ServicePort{
+				{
+					Name:        "metrics",
+					Port:        port,
+					Protocol:    corev1.ProtocolTCP,
+					TargetPort:  intstr.IntOrString{IntVal: port},
+					AppProtocol: pointer.StringPtr("http"),
+				},
+			},
+			Selector:        selector,
+			Type:            corev1.ServiceTypeClusterIP,
+			SessionAffinity: corev1.ServiceAffinityNone,
+			IPFamilyPolicy:  contour.Spec.NetworkPublishing.IPFamilyPolicy,
+			IPFamilies:      contour.Spec.NetworkPublishing.IPFamilies,
+		},
+	}
+}
+
 // DesiredContourService generates the desired Contour Service for the given contour.
 func DesiredContourService(contour *operatorv1alpha1.Contour) *corev1.Service {
 	xdsPort := objcfg.XDSPort
@@ -212,6 +351,8 @@ func DesiredContourService(contour *operatorv1alpha1.Contour) *corev1.Service {
 			Selector:        objdeploy.ContourDeploymentPodSelector().MatchLabels,
 			Type:            corev1.ServiceTypeClusterIP,
 			SessionAffinity: corev1.ServiceAffinityNone,
+			IPFamilyPolicy:  contour.Spec.NetworkPublishing.IPFamilyPolicy,
+			IPFamilies:      contour.Spec.NetworkPublishing.IPFamilies,
 		},
 	}
 	return svc
@@ -228,19 +369,21 @@ func DesiredEnvoyService(contour *operatorv1alpha1.Contour) *corev1.Service {
 			httpFound = true
 
 			ports = append(ports, corev1.ServicePort{
-				Name:       port.Name,
-				Protocol:   corev1.ProtocolTCP,
-				Port:       EnvoyServiceHTTPPort,
-				TargetPort: intstr.IntOrString{IntVal: port.PortNumber},
+				Name:        port.Name,
+				Protocol:    corev1.ProtocolTCP,
+				Port:        envoyServicePort(contour, port.Name, EnvoyServiceHTTPPort),
+				TargetPort:  intstr.IntOrString{IntVal: port.PortNumber},
+				AppProtocol: pointer.StringPtr("http"),
 			})
 		case "https":
 			httpsFound = true
 
 			ports = append(ports, corev1.ServicePort{
-				Name:       port.Name,
-				Protocol:   corev1.ProtocolTCP,
-				Port:       EnvoyServiceHTTPSPort,
-				TargetPort: intstr.IntOrString{IntVal: port.PortNumber},
+				Name:        port.Name,
+				Protocol:    corev1.ProtocolTCP,
+				Port:        envoyServicePort(contour, port.Name, EnvoyServiceHTTPSPort),
+				TargetPort:  intstr.IntOrString{IntVal: port.PortNumber},
+				AppProtocol: pointer.StringPtr("https"),
 			})
 		}
 
@@ -249,6 +392,15 @@ func DesiredEnvoyService(contour *operatorv1alpha1.Contour) *corev1.Service {
 		}
 	}
 
+	for _, port := range contour.Spec.NetworkPublishing.Envoy.ExtraPorts {
+		ports = append(ports, corev1.ServicePort{
+			Name:       port.Name,
+			Protocol:   corev1.ProtocolTCP,
+			Port:       port.PortNumber,
+			TargetPort: intstr.IntOrString{IntVal: port.ContainerPortNumber},
+		})
+	}
+
 	svc := &corev1.Service{
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace:   contour.Spec.Namespace.Name,
@@ -260,47 +412,33 @@ func DesiredEnvoyService(contour *operatorv1alpha1.Contour) *corev1.Service {
 			Ports:           ports,
 			Selector:        objds.EnvoyDaemonSetPodSelector().MatchLabels,
 			SessionAffinity: corev1.ServiceAffinityNone,
+			IPFamilyPolicy:  contour.Spec.NetworkPublishing.IPFamilyPolicy,
+			IPFamilies:      contour.Spec.NetworkPublishing.IPFamilies,
 		},
 	}
 
-	// Add AWS LB annotations based on the network publishing strategy and provider type.
-	if contour.Spec.NetworkPublishing.Envoy.Type == operatorv1alpha1.LoadBalancerServicePublishingType &&
-		contour.Spec.NetworkPublishing.Envoy.LoadBalancer.ProviderParameters.Type == operatorv1alpha1.AWSLoadBalancerProvider {
-		// Add the TCP backend protocol annotation for AWS classic load balancers.
-		if isELB(&contour.Spec.NetworkPublishing.Envoy.LoadBalancer.ProviderParameters) {
-			svc.Annotations[awsLbBackendProtoAnnotation] = "tcp"
-			svc.Annotations[awsLBProxyProtocolAnnotation] = "*"
-		} else {
-			// Annotate the service for an NLB.
-			svc.Annotations[awsLBTypeAnnotation] = "nlb"
+	if contour.Spec.NetworkPublishing.Envoy.SessionAffinity == corev1.ServiceAffinityClientIP {
+		svc.Spec.SessionAffinity = corev1.ServiceAffinityClientIP
+		// Set the timeout explicitly so the Service matches what the API server
+		// defaults and changes to the timeout can be detected.
+		timeout := int32(corev1.DefaultClientIPServiceAffinitySeconds)
+		if cfg := contour.Spec.NetworkPublishing.Envoy.SessionAffinityConfig; cfg != nil &&
+			cfg.ClientIP != nil && cfg.ClientIP.TimeoutSeconds != nil {
+			timeout = *cfg.ClientIP.TimeoutSeconds
+		}
+		svc.Spec.SessionAffinityConfig = &corev1.SessionAffinityConfig{
+			ClientIP: &corev1.ClientIPConfig{TimeoutSeconds: &timeout},
 		}
 	}
 
-	// Add the AllocationIDs annotation if specified by AWS provider parameters.
-	if allocationIDsNeeded(&contour.Spec) {
-		svc.Annotations[awsLBAllocationIDsAnnotation] = strings.Join(contour.Spec.NetworkPublishing.Envoy.LoadBalancer.ProviderParameters.AWS.AllocationIDs, ",")
-	}
-
-	// Add the ResourceGroup annotation if specified by Azure provider parameters.
-	if resourceGroupNeeded(&contour.Spec) {
-		svc.Annotations[azureLBResourceGroupAnnotation] = *contour.Spec.NetworkPublishing.Envoy.LoadBalancer.ProviderParameters.Azure.ResourceGroup
-	}
-
-	// Add the Subnet annotation if specified by provider parameters.
-	if subnetNeeded(&contour.Spec) {
-		if contour.Spec.NetworkPublishing.Envoy.LoadBalancer.ProviderParameters.Type == operatorv1alpha1.AzureLoadBalancerProvider {
-			svc.Annotations[azureLBSubnetAnnotation] = *contour.Spec.NetworkPublishing.Envoy.LoadBalancer.ProviderParameters.Azure.Subnet
-		} else if contour.Spec.NetworkPublishing.Envoy.LoadBalancer.ProviderParameters.Type == operatorv1alpha1.GCPLoadBalancerProvider {
-			svc.Annotations[gcpLBSubnetAnnotation] = *contour.Spec.NetworkPublishing.Envoy.LoadBalancer.ProviderParameters.GCP.Subnet
-		}
+	if contour.Spec.NetworkPublishing.Envoy.Hostname != nil {
+		svc.Annotations[externalDNSHostnameAnnotation] = *contour.Spec.NetworkPublishing.Envoy.Hostname
 	}
 
-	// Add LoadBalancerIP parameter if specified by provider parameters.
-	if loadBalancerAddressNeeded(&contour.Spec) {
-		if contour.Spec.NetworkPublishing.Envoy.LoadBalancer.ProviderParameters.Type == operatorv1alpha1.AzureLoadBalancerProvider {
-			svc.Spec.LoadBalancerIP = *contour.Spec.NetworkPublishing.Envoy.LoadBalancer.ProviderParameters.Azure.Address
-		} else if contour.Spec.NetworkPublishing.Envoy.LoadBalancer.ProviderParameters.Type == operatorv1alpha1.GCPLoadBalancerProvider {
-			svc.Spec.LoadBalancerIP = *contour.Spec.NetworkPublishing.Envoy.LoadBalancer.ProviderParameters.GCP.Address
+	// Apply the settings specific to the load balancer's infrastructure provider.
+	if contour.Spec.NetworkPublishing.Envoy.Type == operatorv1alpha1.LoadBalancerServicePublishingType {
+		if p, ok := loadBalancerProviders[contour.Spec.NetworkPublishing.Envoy.LoadBalancer.ProviderParameters.Type]; ok {
+			p.configureService(contour, svc)
 		}
 	}
 
@@ -312,6 +450,11 @@ func DesiredEnvoyService(contour *operatorv1alpha1.Contour) *corev1.Service {
 	switch epType {
 	case operatorv1alpha1.LoadBalancerServicePublishingType:
 		svc.Spec.Type = corev1.ServiceTypeLoadBalancer
+		svc.Spec.LoadBalancerClass = contour.Spec.NetworkPublishing.Envoy.LoadBalancer.LoadBalancerClass
+		svc.Spec.AllocateLoadBalancerNodePorts = contour.Spec.NetworkPublishing.Envoy.LoadBalancer.AllocateLoadBalancerNodePorts
+		if port := contour.Spec.NetworkPublishing.Envoy.LoadBalancer.HealthCheckNodePort; port != nil {
+			svc.Spec.HealthCheckNodePort = *port
+		}
 		isInternal := contour.Spec.NetworkPublishing.Envoy.LoadBalancer.Scope == operatorv1alpha1.InternalLoadBalancer
 		if isInternal {
 			provider := contour.Spec.NetworkPublishing.Envoy.LoadBalancer.ProviderParameters.Type
@@ -339,29 +482,35 @@ func DesiredEnvoyService(contour *operatorv1alpha1.Contour) *corev1.Service {
 	return svc
 }
 
+// envoyServicePort returns the Envoy Service port number for the port named
+// name, or def if contour does not specify one.
+func envoyServicePort(contour *operatorv1alpha1.Contour, name string, def int32) int32 {
+	for _, p := range contour.Spec.NetworkPublishing.Envoy.ServicePorts {
+		if p.Name == name {
+			return p.PortNumber
+		}
+	}
+	return def
+}
+
 // currentContourService returns the current Contour Service for the provided contour.
 func currentContourService(ctx context.Context, cli client.Client, contour *operatorv1alpha1.Contour) (*corev1.Service, error) {
-	current := &corev1.Service{}
-	key := types.NamespacedName{
-		Namespace: contour.Spec.Namespace.Name,
-		Name:      contourSvcName,
-	}
-	err := cli.Get(ctx, key, current)
-	if err != nil {
-		return nil, err
-	}
-	return current, nil
+	return currentService(ctx, cli, contour.Spec.Namespace.Name, contourSvcName)
+}
+
+// CurrentEnvoyService returns the current Envoy Service for the provided contour.
+func CurrentEnvoyService(ctx context.Context, cli client.Client, contour *operatorv1alpha1.Contour) (*corev1.Service, error) {
+	return currentService(ctx, cli, contour.Spec.Namespace.Name, envoySvcName)
 }
 
-// currentEnvoyService returns the current Envoy Service for the provided contour.
-func currentEnvoyService(ctx context.Context, cli client.Client, contour *operatorv1alpha1.Contour) (*corev1.Service, error) {
+// currentService returns the Service named name in namespace ns.
+func currentService(ctx context.Context, cli client.Client, ns, name string) (*corev1.Service, error) {
 	current := &corev1.Service{}
 	key := types.NamespacedName{
-		Namespace: contour.Spec.Namespace.Name,
-		Name:      envoySvcName,
+		Namespace: ns,
+		Name:      name,
 	}
-	err := cli.Get(ctx, key, current)
-	if err != nil {
+	if err := cli.Get(ctx, key, current); err != nil {
 		return nil, err
 	}
 	return current, nil
@@ -405,6 +554,8 @@ func updateEnvoyServiceIfNeeded(ctx context.Context, cli client.Client, contour
 		// Add additional network publishing types as they are introduced.
 		default:
 			// LoadBalancerService is the default network publishing type.
+			// A changed loadBalancerClass is surfaced through the contour's
+			// status since the Service must be recreated to change it.
 			updated, needed = equality.LoadBalancerServiceChanged(current, desired)
 		}
 		if needed {
@@ -416,56 +567,3 @@ func updateEnvoyServiceIfNeeded(ctx context.Context, cli client.Client, contour
 	}
 	return nil
 }
-
-// isELB returns true if params is an AWS Classic ELB.
-func isELB(params *operatorv1alpha1.ProviderLoadBalancerParameters) bool {
-	return params.Type == operatorv1alpha1.AWSLoadBalancerProvider &&
-		(params.AWS == nil || params.AWS.Type == operatorv1alpha1.AWSClassicLoadBalancer)
-}
-
-// allocationIDsNeeded returns true if "service.beta.kubernetes.io/aws-load-balancer-eip-allocations"
-// annotation is needed based on the provided spec.
-func allocationIDsNeeded(spec *operatorv1alpha1.ContourSpec) bool {
-	return spec.NetworkPublishing.Envoy.Type == operatorv1alpha1.LoadBalancerServicePublishingType &&
-		spec.NetworkPublishing.Envoy.LoadBalancer.Scope == "External" &&
-		spec.NetworkPublishing.Envoy.LoadBalancer.ProviderParameters.Type == operatorv1alpha1.AWSLoadBalancerProvider &&
-		spec.NetworkPublishing.Envoy.LoadBalancer.ProviderParameters.AWS != nil &&
-		spec.NetworkPublishing.Envoy.LoadBalancer.ProviderParameters.AWS.Type == operatorv1alpha1.AWSNetworkLoadBalancer &&
-		spec.NetworkPublishing.Envoy.LoadBalancer.ProviderParameters.AWS.AllocationIDs != nil
-}
-
-// resourceGroupNeeded returns true if "service.beta.kubernetes.io/azure-load-balancer-resource-group"
-// annotation is needed based on the provided spec.
-func resourceGroupNeeded(spec *operatorv1alpha1.ContourSpec) bool {
-	return spec.NetworkPublishing.Envoy.Type == operatorv1alpha1.LoadBalancerServicePublishingType &&
-		spec.NetworkPublishing.Envoy.LoadBalancer.ProviderParameters.Type == operatorv1alpha1.AzureLoadBalancerProvider &&
-		spec.NetworkPublishing.Envoy.LoadBalancer.ProviderParameters.Azure != nil &&
-		spec.NetworkPublishing.Envoy.LoadBalancer.Scope == "External" &&
-		spec.NetworkPublishing.Envoy.LoadBalancer.ProviderParameters.Azure.ResourceGroup != nil
-}
-
-// subnetNeeded returns true if "service.beta.kubernetes.io/azure-load-balancer-internal-subnet" or
-// "networking.gke.io/internal-load-balancer-subnet" annotation is needed based
-// on the provided spec.
-func subnetNeeded(spec *operatorv1alpha1.ContourSpec) bool {
-	return spec.NetworkPublishing.Envoy.Type == operatorv1alpha1.LoadBalancerServicePublishingType &&
-		spec.NetworkPublishing.Envoy.LoadBalancer.Scope == "Internal" &&
-		((spec.NetworkPublishing.Envoy.LoadBalancer.ProviderParameters.Type == operatorv1alpha1.AzureLoadBalancerProvider &&
-			spec.NetworkPublishing.Envoy.LoadBalancer.ProviderParameters.Azure != nil &&
-			spec.NetworkPublishing.Envoy.LoadBalancer.ProviderParameters.Azure.Subnet != nil) ||
-			(spec.NetworkPublishing.Envoy.LoadBalancer.ProviderParameters.Type == operatorv1alpha1.GCPLoadBalancerProvider &&
-				spec.NetworkPublishing.Envoy.LoadBalancer.ProviderParameters.GCP != nil &&
-				spec.NetworkPublishing.Envoy.LoadBalancer.ProviderParameters.GCP.Subnet != nil))
-}
-
-// loadBalancerAddressNeeded returns true if LoadBalancerIP parameter of service
-// is needed based on provided spec.
-func loadBalancerAddressNeeded(spec *operatorv1alpha1.ContourSpec) bool {
-	return spec.NetworkPublishing.Envoy.Type == operatorv1alpha1.LoadBalancerServicePublishingType &&
-		((spec.NetworkPublishing.Envoy.LoadBalancer.ProviderParameters.Type == operatorv1alpha1.AzureLoadBalancerProvider &&
-			spec.NetworkPublishing.Envoy.LoadBalancer.ProviderParameters.Azure != nil &&
-			spec.NetworkPublishing.Envoy.LoadBalancer.ProviderParameters.Azure.Address != nil) ||
-			(spec.NetworkPublishing.Envoy.LoadBalancer.ProviderParameters.Type == operatorv1alpha1.GCPLoadBalancerProvider &&
-				spec.NetworkPublishing.Envoy.LoadBalancer.ProviderParameters.GCP != nil &&
-				spec.NetworkPublishing.Envoy.LoadBalancer.ProviderParameters.GCP.Address != nil))
-}
@@ -15,6 +15,7 @@ package service
 
 import (
 	"fmt"
+	"reflect"
 	"sort"
 	"testing"
 
@@ -24,6 +25,7 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/pointer"
 )
 
 func checkServiceHasPort(t *testing.T, svc *corev1.Service, port int32) {
@@ -109,6 +111,20 @@ func checkServiceHasAnnotations(t *testing.T, svc *corev1.Service, expectedKeys
 	}
 }
 
+func checkServiceHasAppProtocol(t *testing.T, svc *corev1.Service, name, protocol string) {
+	t.Helper()
+
+	for _, p := range svc.Spec.Ports {
+		if p.Name == name {
+			if p.AppProtocol == nil || *p.AppProtocol != protocol {
+				t.Errorf("service port %q is missing app protocol %q", name, protocol)
+			}
+			return
+		}
+	}
+	t.Errorf("service is missing port name %q", name)
+}
+
 func checkServiceHasType(t *testing.T, svc *corev1.Service, svcType corev1.ServiceType) {
 	t.Helper()
 
@@ -133,6 +149,21 @@ func checkServiceHasLoadBalancerAddress(t *testing.T, svc *corev1.Service, addre
 	}
 }
 
+func checkServiceHasSessionAffinity(t *testing.T, svc *corev1.Service, affinity corev1.ServiceAffinity, timeout *int32) {
+	t.Helper()
+
+	if svc.Spec.SessionAffinity != affinity {
+		t.Errorf("service is missing session affinity %s", affinity)
+	}
+	var actual *int32
+	if svc.Spec.SessionAffinityConfig != nil && svc.Spec.SessionAffinityConfig.ClientIP != nil {
+		actual = svc.Spec.SessionAffinityConfig.ClientIP.TimeoutSeconds
+	}
+	if !reflect.DeepEqual(actual, timeout) {
+		t.Errorf("service has unexpected session affinity timeout %v", actual)
+	}
+}
+
 func TestDesiredContourService(t *testing.T) {
 	name := "svc-test"
 	cfg := objcontour.Config{
@@ -151,6 +182,38 @@ func TestDesiredContourService(t *testing.T) {
 	checkServiceHasPortProtocol(t, svc, corev1.ProtocolTCP)
 }
 
+func TestDesiredMetricsServices(t *testing.T) {
+	name := "svc-test"
+	cfg := objcontour.Config{
+		Name:        name,
+		Namespace:   fmt.Sprintf("%s-ns", name),
+		SpecNs:      "projectcontour",
+		RemoveNs:    false,
+		NetworkType: operatorv1alpha1.LoadBalancerServicePublishingType,
+	}
+	cntr := objcontour.New(cfg)
+
+	svc := DesiredContourMetricsService(cntr)
+	checkServiceHasType(t, svc, corev1.ServiceTypeClusterIP)
+	checkServiceHasPort(t, svc, objcfg.ContourMetricsPort)
+	checkServiceHasTargetPort(t, svc, objcfg.ContourMetricsPort)
+	checkServiceHasPortName(t, svc, "metrics")
+	checkServiceHasAnnotations(t, svc, "prometheus.io/scrape", "prometheus.io/port", "prometheus.io/path")
+	checkServiceHasAppProtocol(t, svc, "metrics", "http")
+	if svc.Labels["app.kubernetes.io/name"] != "contour" {
+		t.Errorf("service has unexpected %q label %q", "app.kubernetes.io/name", svc.Labels["app.kubernetes.io/name"])
+	}
+
+	svc = DesiredEnvoyMetricsService(cntr)
+	checkServiceHasType(t, svc, corev1.ServiceTypeClusterIP)
+	checkServiceHasPort(t, svc, objcfg.EnvoyMetricsPort)
+	checkServiceHasTargetPort(t, svc, objcfg.EnvoyMetricsPort)
+	checkServiceHasPortName(t, svc, "metrics")
+	if svc.Labels["app.kubernetes.io/name"] != "envoy" {
+		t.Errorf("service has unexpected %q label %q", "app.kubernetes.io/name", svc.Labels["app.kubernetes.io/name"])
+	}
+}
+
 func TestDesiredEnvoyService(t *testing.T) {
 	name := "svc-test"
 	loadBalancerAddress := "1.2.3.4"
@@ -179,6 +242,48 @@ func TestDesiredEnvoyService(t *testing.T) {
 	checkServiceHasPortName(t, svc, "http")
 	checkServiceHasPortName(t, svc, "https")
 	checkServiceHasPortProtocol(t, svc, corev1.ProtocolTCP)
+	checkServiceHasAppProtocol(t, svc, "http", "http")
+	checkServiceHasAppProtocol(t, svc, "https", "https")
+
+	// Check the service ports can be overridden.
+	cntr.Spec.NetworkPublishing.Envoy.ServicePorts = []operatorv1alpha1.ServicePort{
+		{Name: "http", PortNumber: 8080},
+		{Name: "https", PortNumber: 8443},
+	}
+	svc = DesiredEnvoyService(cntr)
+	checkServiceHasPort(t, svc, 8080)
+	checkServiceHasPort(t, svc, 8443)
+	cntr.Spec.NetworkPublishing.Envoy.ServicePorts = nil
+
+	// Check extra ports are appended to the service.
+	cntr.Spec.NetworkPublishing.Envoy.ExtraPorts = []operatorv1alpha1.ExtraPort{
+		{Name: "tcp-proxy", PortNumber: 9000, ContainerPortNumber: 9001},
+	}
+	svc = DesiredEnvoyService(cntr)
+	checkServiceHasPort(t, svc, 9000)
+	checkServiceHasTargetPort(t, svc, 9001)
+	checkServiceHasPortName(t, svc, "tcp-proxy")
+	cntr.Spec.NetworkPublishing.Envoy.ExtraPorts = nil
+
+	// Check the external-dns hostname annotation.
+	hostname := "ingress.example.com"
+	cntr.Spec.NetworkPublishing.Envoy.Hostname = &hostname
+	svc = DesiredEnvoyService(cntr)
+	checkServiceHasAnnotations(t, svc, externalDNSHostnameAnnotation)
+	cntr.Spec.NetworkPublishing.Envoy.Hostname = nil
+
+	// Check session affinity defaults to None and the ClientIP timeout is defaulted.
+	checkServiceHasSessionAffinity(t, svc, corev1.ServiceAffinityNone, nil)
+	cntr.Spec.NetworkPublishing.Envoy.SessionAffinity = corev1.ServiceAffinityClientIP
+	svc = DesiredEnvoyService(cntr)
+	checkServiceHasSessionAffinity(t, svc, corev1.ServiceAffinityClientIP, pointer.Int32Ptr(corev1.DefaultClientIPServiceAffinitySeconds))
+	cntr.Spec.NetworkPublishing.Envoy.SessionAffinityConfig = &corev1.SessionAffinityConfig{
+		ClientIP: &corev1.ClientIPConfig{TimeoutSeconds: pointer.Int32Ptr(600)},
+	}
+	svc = DesiredEnvoyService(cntr)
+	checkServiceHasSessionAffinity(t, svc, corev1.ServiceAffinityClientIP, pointer.Int32Ptr(600))
+	cntr.Spec.NetworkPublishing.Envoy.SessionAffinity = ""
+	cntr.Spec.NetworkPublishing.Envoy.SessionAffinityConfig = nil
 
 	// Check LB annotations for the different provider types, starting with AWS ELB (the default
 	// if AWS provider params are not passed).
@@ -190,6 +295,28 @@ func TestDesiredEnvoyService(t *testing.T) {
 	checkServiceHasExternalTrafficPolicy(t, svc, corev1.ServiceExternalTrafficPolicyTypeLocal)
 	checkServiceHasAnnotations(t, svc, awsLbBackendProtoAnnotation, awsLBProxyProtocolAnnotation)
 
+	// Check the load balancer class and node port allocation are passed through.
+	lbClass := "service.k8s.aws/nlb"
+	cntr.Spec.NetworkPublishing.Envoy.LoadBalancer.LoadBalancerClass = &lbClass
+	cntr.Spec.NetworkPublishing.Envoy.LoadBalancer.AllocateLoadBalancerNodePorts = pointer.BoolPtr(false)
+	svc = DesiredEnvoyService(cntr)
+	if svc.Spec.LoadBalancerClass == nil || *svc.Spec.LoadBalancerClass != lbClass {
+		t.Errorf("service is missing load balancer class %s", lbClass)
+	}
+	if svc.Spec.AllocateLoadBalancerNodePorts == nil || *svc.Spec.AllocateLoadBalancerNodePorts {
+		t.Errorf("service is missing allocateLoadBalancerNodePorts=false")
+	}
+	cntr.Spec.NetworkPublishing.Envoy.LoadBalancer.LoadBalancerClass = nil
+	cntr.Spec.NetworkPublishing.Envoy.LoadBalancer.AllocateLoadBalancerNodePorts = nil
+
+	// Check the health check node port can be pinned.
+	cntr.Spec.NetworkPublishing.Envoy.LoadBalancer.HealthCheckNodePort = pointer.Int32Ptr(32000)
+	svc = DesiredEnvoyService(cntr)
+	if svc.Spec.HealthCheckNodePort != 32000 {
+		t.Errorf("service is missing health check node port %d", 32000)
+	}
+	cntr.Spec.NetworkPublishing.Envoy.LoadBalancer.HealthCheckNodePort = nil
+
 	// Test proxy protocol for AWS Classic load balancer (when provider params are specified).
 	elbParams := operatorv1alpha1.ProviderLoadBalancerParameters{
 		Type: operatorv1alpha1.AWSLoadBalancerProvider,
@@ -209,6 +336,16 @@ func TestDesiredEnvoyService(t *testing.T) {
 	// NLBs should not have PROXY protocol or backend protocol annotations.
 	checkServiceHasAnnotations(t, svc, awsLBTypeAnnotation, awsLBAllocationIDsAnnotation)
 
+	// Check PROXY protocol can be explicitly enabled for NLBs and disabled for ELBs.
+	cntr.Spec.NetworkPublishing.Envoy.LoadBalancer.ProxyProtocol = pointer.BoolPtr(true)
+	svc = DesiredEnvoyService(cntr)
+	checkServiceHasAnnotations(t, svc, awsLBTypeAnnotation, awsLBAllocationIDsAnnotation, awsLBProxyProtocolAnnotation)
+	cntr.Spec.NetworkPublishing.Envoy.LoadBalancer.ProviderParameters = elbParams
+	cntr.Spec.NetworkPublishing.Envoy.LoadBalancer.ProxyProtocol = pointer.BoolPtr(false)
+	svc = DesiredEnvoyService(cntr)
+	checkServiceHasAnnotations(t, svc, awsLbBackendProtoAnnotation)
+	cntr.Spec.NetworkPublishing.Envoy.LoadBalancer.ProxyProtocol = nil
+
 	// Check Azure external load balancer type.
 	azureParams := operatorv1alpha1.ProviderLoadBalancerParameters{
 		Type:  operatorv1alpha1.AzureLoadBalancerProvider,
@@ -219,6 +356,13 @@ func TestDesiredEnvoyService(t *testing.T) {
 	checkServiceHasLoadBalancerAddress(t, svc, loadBalancerAddress)
 	checkServiceHasAnnotations(t, svc, azureLBResourceGroupAnnotation)
 
+	// Check an Azure external load balancer with a public IP referenced by name.
+	publicIPName := "contour-pip"
+	azureParams.Azure.PublicIPName = &publicIPName
+	svc = DesiredEnvoyService(cntr)
+	checkServiceHasAnnotations(t, svc, azureLBResourceGroupAnnotation, azurePIPNameAnnotation)
+	azureParams.Azure.PublicIPName = nil
+
 	// Check GCP external load balancer type.
 	gcpParams := operatorv1alpha1.ProviderLoadBalancerParameters{
 		Type: operatorv1alpha1.GCPLoadBalancerProvider,
@@ -228,6 +372,28 @@ func TestDesiredEnvoyService(t *testing.T) {
 	svc = DesiredEnvoyService(cntr)
 	checkServiceHasLoadBalancerAddress(t, svc, loadBalancerAddress)
 
+	// Check GCP network endpoint groups.
+	gcpParams.GCP.NetworkEndpointGroups = &operatorv1alpha1.GCPNetworkEndpointGroups{
+		Ingress:      true,
+		ExposedPorts: []int32{443, 80},
+	}
+	svc = DesiredEnvoyService(cntr)
+	checkServiceHasAnnotations(t, svc, gcpNEGAnnotation)
+	if neg := svc.Annotations[gcpNEGAnnotation]; neg != `{"ingress":true,"exposed_ports":{"443":{},"80":{}}}` {
+		t.Errorf("service has unexpected %q annotation %s", gcpNEGAnnotation, neg)
+	}
+	gcpParams.GCP.NetworkEndpointGroups = nil
+
+	// Check MetalLB address pool and IP sharing annotations.
+	addressPool := "public"
+	sharingKey := "contour"
+	cntr.Spec.NetworkPublishing.Envoy.LoadBalancer.ProviderParameters = operatorv1alpha1.ProviderLoadBalancerParameters{
+		Type:    operatorv1alpha1.MetalLBLoadBalancerProvider,
+		MetalLB: &operatorv1alpha1.MetalLBLoadBalancerParameters{AddressPool: &addressPool, AllowSharedIP: &sharingKey},
+	}
+	svc = DesiredEnvoyService(cntr)
+	checkServiceHasAnnotations(t, svc, metalLBAddressPoolAnnotation, metalLBAllowSharedIPAnnotation)
+
 	// Test an internal ELB
 	cntr.Spec.NetworkPublishing.Envoy.LoadBalancer.Scope = operatorv1alpha1.InternalLoadBalancer
 	cntr.Spec.NetworkPublishing.Envoy.LoadBalancer.ProviderParameters = elbParams
@@ -260,3 +426,35 @@ func TestDesiredEnvoyService(t *testing.T) {
 	checkServiceHasType(t, svc, corev1.ServiceTypeClusterIP)
 	checkServiceHasAnnotations(t, svc) // passing no keys means we expect no annotations
 }
+
+type fakeProvider struct{}
+
+func (fakeProvider) configureService(_ *operatorv1alpha1.Contour, svc *corev1.Service) {
+	svc.Annotations["fake"] = "true"
+	svc.Spec.LoadBalancerIP = "1.2.3.4"
+}
+
+func TestDesiredEnvoyServiceProvider(t *testing.T) {
+	orig := loadBalancerProviders[operatorv1alpha1.AWSLoadBalancerProvider]
+	loadBalancerProviders[operatorv1alpha1.AWSLoadBalancerProvider] = fakeProvider{}
+	defer func() { loadBalancerProviders[operatorv1alpha1.AWSLoadBalancerProvider] = orig }()
+
+	name := "svc-test"
+	cfg := objcontour.Config{
+		Name:        name,
+		Namespace:   fmt.Sprintf("%s-ns", name),
+		SpecNs:      "projectcontour",
+		RemoveNs:    false,
+		NetworkType: operatorv1alpha1.LoadBalancerServicePublishingType,
+	}
+	cntr := objcontour.New(cfg)
+	cntr.Spec.NetworkPublishing.Envoy.LoadBalancer.ProviderParameters.Type = operatorv1alpha1.AWSLoadBalancerProvider
+	svc := DesiredEnvoyService(cntr)
+	checkServiceHasAnnotations(t, svc, "fake")
+	checkServiceHasLoadBalancerAddress(t, svc, "1.2.3.4")
+
+	// The provider is not used for other network publishing types.
+	cntr.Spec.NetworkPublishing.Envoy.Type = operatorv1alpha1.ClusterIPServicePublishingType
+	svc = DesiredEnvoyService(cntr)
+	checkServiceHasAnnotations(t, svc)
+}
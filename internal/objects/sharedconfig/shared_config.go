@@ -20,4 +20,22 @@ const (
 	EnvoyInsecureContainerPort = int32(8080)
 	// EnvoySecureContainerPort is the network port number of Envoy's secure listener.
 	EnvoySecureContainerPort = int32(8443)
+	// ContourMetricsPort is the network port number of Contour's metrics listener.
+	ContourMetricsPort = int32(8000)
+	// ContourHealthPort is the network port number of Contour's health
+	// listener when the metrics listener is served over TLS.
+	ContourHealthPort = int32(8003)
+	// ContourCertsDir is the directory Contour's certificates are mounted in.
+	ContourCertsDir = "/certs"
+	// ContourMetricsCertsDir is the directory the user-provided certificate
+	// of Contour's metrics listener is mounted in.
+	ContourMetricsCertsDir = "/metrics-certs"
+	// EnvoyHTTPHostPort is the node port number Envoy's insecure listener
+	// is bound to when using host ports.
+	EnvoyHTTPHostPort = int32(80)
+	// EnvoyHTTPSHostPort is the node port number Envoy's secure listener
+	// is bound to when using host ports.
+	EnvoyHTTPSHostPort = int32(443)
+	// EnvoyMetricsPort is the network port number of Envoy's metrics listener.
+	EnvoyMetricsPort = int32(8002)
 )
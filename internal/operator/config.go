@@ -42,6 +42,19 @@ type Config struct {
 	// LeaderElectionID determines the name of the configmap that leader election will
 	// use for holding the leader lock.
 	LeaderElectionID string
+
+	// ImagePullSecrets are the names of the image pull secrets used by the
+	// Contour and Envoy pods of Contours that don't specify imagePullSecretRefs.
+	ImagePullSecrets []string
+
+	// MirrorImagePullSecrets determines whether ImagePullSecrets are mirrored
+	// from OperatorNamespace into the spec.namespace.name of each Contour.
+	MirrorImagePullSecrets bool
+
+	// OperatorNamespace is the namespace the operator runs in. Image pull
+	// secrets marked for mirroring are copied from this namespace. If empty,
+	// they are copied from the namespace of each Contour.
+	OperatorNamespace string
 }
 
 // DefaultConfig returns an operator config using default values.
@@ -20,10 +20,14 @@ import (
 	"github.com/go-logr/logr"
 	operatorv1alpha1 "github.com/projectcontour/contour-operator/api/v1alpha1"
 	"github.com/projectcontour/contour-operator/internal/controller"
+	objsecret "github.com/projectcontour/contour-operator/internal/objects/secret"
+	corev1 "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/rest"
 	controller_runtime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
@@ -51,7 +55,7 @@ type Operator struct {
 	config  *Config
 }
 
-// +kubebuilder:rbac:groups=operator.projectcontour.io,resources=contours,verbs=get;list;watch;update
+// +kubebuilder:rbac:groups=operator.projectcontour.io,resources=contours,verbs=get;list;watch;update;delete
 // +kubebuilder:rbac:groups=operator.projectcontour.io,resources=contours/status,verbs=get;update;patch
 // cert-gen needs create/update secrets.
 // +kubebuilder:rbac:groups="",resources=namespaces;secrets;serviceaccounts;services,verbs=get;list;watch;delete;create;update
@@ -63,6 +67,7 @@ type Operator struct {
 // Note, ReferencePolicy does not currently have a .status field so it's omitted from the below.
 // +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=gatewayclasses/status;gateways/status;httproutes/status;tlsroutes/status,verbs=create;get;update
 // +kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=get;list;watch
+// +kubebuilder:rbac:groups=networking.k8s.io,resources=ingressclasses,verbs=get;list;watch;delete;create;update
 // +kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses/status,verbs=create;get;update
 // +kubebuilder:rbac:groups=projectcontour.io,resources=httpproxies;tlscertificatedelegations;extensionservices;contourconfigurations,verbs=get;list;watch
 // +kubebuilder:rbac:groups=projectcontour.io,resources=httpproxies/status;extensionservices/status;contourconfigurations/status,verbs=create;get;update
@@ -70,18 +75,28 @@ type Operator struct {
 // +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;delete;create;update
 // +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;delete;create;update
 // +kubebuilder:rbac:groups=apps,resources=daemonsets,verbs=get;list;watch;delete;create;update
+// +kubebuilder:rbac:groups=autoscaling,resources=horizontalpodautoscalers,verbs=get;list;watch;delete;create;update
+// +kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=get;list;watch;delete;create;update
 // +kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions,verbs=get;list
 
 // New creates a new operator from cliCfg and operatorConfig.
 func New(cliCfg *rest.Config, operatorConfig *Config) (*Operator, error) {
+	// Secrets are read directly since the cache only holds mirrored secrets.
 	nonCached := []client.Object{&operatorv1alpha1.Contour{}, &gatewayv1alpha2.GatewayClass{},
-		&gatewayv1alpha2.Gateway{}, &apiextensionsv1.CustomResourceDefinition{}}
+		&gatewayv1alpha2.Gateway{}, &apiextensionsv1.CustomResourceDefinition{}, &corev1.Secret{}}
 	mgrOpts := manager.Options{
 		Scheme:                GetOperatorScheme(),
 		LeaderElection:        operatorConfig.LeaderElection,
 		LeaderElectionID:      operatorConfig.LeaderElectionID,
 		MetricsBindAddress:    operatorConfig.MetricsBindAddress,
 		ClientDisableCacheFor: nonCached,
+		// Only cache the Secrets mirrored by the operator instead of every
+		// Secret in the cluster.
+		NewCache: cache.BuilderWithOptions(cache.Options{
+			SelectorsByObject: cache.SelectorsByObject{
+				&corev1.Secret{}: {Label: labels.SelectorFromSet(labels.Set{objsecret.MirroredSecretLabel: "true"})},
+			},
+		}),
 	}
 	mgr, err := controller_runtime.NewManager(cliCfg, mgrOpts)
 	if err != nil {
@@ -90,8 +105,11 @@ func New(cliCfg *rest.Config, operatorConfig *Config) (*Operator, error) {
 
 	// Create and register the contour controller with the operator manager.
 	if _, err := controller.New(mgr, controller.Config{
-		ContourImage: operatorConfig.ContourImage,
-		EnvoyImage:   operatorConfig.EnvoyImage,
+		ContourImage:           operatorConfig.ContourImage,
+		EnvoyImage:             operatorConfig.EnvoyImage,
+		ImagePullSecrets:       operatorConfig.ImagePullSecrets,
+		MirrorImagePullSecrets: operatorConfig.MirrorImagePullSecrets,
+		OperatorNamespace:      operatorConfig.OperatorNamespace,
 	}); err != nil {
 		return nil, fmt.Errorf("failed to create contour controller: %w", err)
 	}
@@ -18,6 +18,7 @@ import (
 	"strings"
 
 	operatorv1alpha1 "github.com/projectcontour/contour-operator/api/v1alpha1"
+	"github.com/projectcontour/contour-operator/internal/equality"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -28,9 +29,17 @@ import (
 // clock is to enable unit testing
 var clock utilclock.Clock = utilclock.RealClock{}
 
+// envoyWorkload is the availability of the workload running Envoy.
+type envoyWorkload struct {
+	// kind is the lowercase kind of the workload, i.e. "daemonset" or "deployment".
+	kind string
+	// available is the number of available Envoy pods.
+	available int32
+}
+
 // computeContourAvailableCondition computes the contour Available status condition
-// type based on deployment, ds, set, exists and admitted.
-func computeContourAvailableCondition(deployment *appsv1.Deployment, ds *appsv1.DaemonSet) metav1.Condition {
+// type based on deployment, envoy, set, exists and admitted.
+func computeContourAvailableCondition(deployment *appsv1.Deployment, envoy *envoyWorkload) metav1.Condition {
 	switch {
 	default:
 		if deployment == nil {
@@ -41,22 +50,22 @@ func computeContourAvailableCondition(deployment *appsv1.Deployment, ds *appsv1.
 				Message: "Contour deployment does not exist.",
 			}
 		}
-		if ds == nil {
+		if envoy == nil {
 			return metav1.Condition{
 				Type:    operatorv1alpha1.ContourAvailableConditionType,
 				Status:  metav1.ConditionFalse,
 				Reason:  "ContourUnavailable",
-				Message: "Envoy daemonset does not exist.",
+				Message: "Envoy workload does not exist.",
 			}
 		}
-		dsAvailable := ds.Status.NumberAvailable > 0
+		envoyAvailable := envoy.available > 0
 		for _, cond := range deployment.Status.Conditions {
 			if cond.Type != appsv1.DeploymentAvailable {
 				continue
 			}
 			switch {
 			case cond.Status == corev1.ConditionTrue:
-				if dsAvailable {
+				if envoyAvailable {
 					return metav1.Condition{
 						Type:    operatorv1alpha1.ContourAvailableConditionType,
 						Status:  metav1.ConditionTrue,
@@ -68,10 +77,10 @@ func computeContourAvailableCondition(deployment *appsv1.Deployment, ds *appsv1.
 					Type:    operatorv1alpha1.ContourAvailableConditionType,
 					Status:  metav1.ConditionFalse,
 					Reason:  "ContourUnavailable",
-					Message: "Envoy daemonset does not have minimum availability.",
+					Message: fmt.Sprintf("Envoy %s does not have minimum availability.", envoy.kind),
 				}
 			case cond.Status == corev1.ConditionFalse:
-				if dsAvailable {
+				if envoyAvailable {
 					return metav1.Condition{
 						Type:    operatorv1alpha1.ContourAvailableConditionType,
 						Status:  metav1.ConditionFalse,
@@ -83,8 +92,8 @@ func computeContourAvailableCondition(deployment *appsv1.Deployment, ds *appsv1.
 					Type:   operatorv1alpha1.ContourAvailableConditionType,
 					Status: metav1.ConditionFalse,
 					Reason: "ContourUnavailable",
-					Message: fmt.Sprintf("Envoy daemonset does not have minimum availability. Contour %s",
-						strings.ToLower(cond.Message)),
+					Message: fmt.Sprintf("Envoy %s does not have minimum availability. Contour %s",
+						envoy.kind, strings.ToLower(cond.Message)),
 				}
 			case cond.Status == corev1.ConditionUnknown:
 				return metav1.Condition{
@@ -105,6 +114,54 @@ func computeContourAvailableCondition(deployment *appsv1.Deployment, ds *appsv1.
 	}
 }
 
+// computeContourValidCondition computes the contour Valid status condition
+// type based on validationErr, the error returned by validating the contour.
+func computeContourValidCondition(validationErr error) metav1.Condition {
+	if validationErr != nil {
+		return metav1.Condition{
+			Type:    operatorv1alpha1.ContourValidConditionType,
+			Status:  metav1.ConditionFalse,
+			Reason:  "ValidationFailed",
+			Message: fmt.Sprintf("Contour is invalid: %v", validationErr),
+		}
+	}
+	return metav1.Condition{
+		Type:    operatorv1alpha1.ContourValidConditionType,
+		Status:  metav1.ConditionTrue,
+		Reason:  "ContourValid",
+		Message: "Contour is valid.",
+	}
+}
+
+// computeEnvoyServiceSyncedCondition computes the contour EnvoyServiceSynced
+// status condition type based on current, the Envoy Service if it exists, and
+// desired. The condition is false when the Service must be recreated to apply
+// an immutable field, which the operator leaves to the user since recreating
+// the Service replaces its load balancer and address.
+func computeEnvoyServiceSyncedCondition(current, desired *corev1.Service) metav1.Condition {
+	if current != nil && current.Spec.Type == corev1.ServiceTypeLoadBalancer &&
+		equality.LoadBalancerClassChanged(current, desired) {
+		currentClass := "<none>"
+		if current.Spec.LoadBalancerClass != nil {
+			currentClass = *current.Spec.LoadBalancerClass
+		}
+		return metav1.Condition{
+			Type:   operatorv1alpha1.EnvoyServiceSyncedConditionType,
+			Status: metav1.ConditionFalse,
+			Reason: "LoadBalancerClassImmutable",
+			Message: fmt.Sprintf("Envoy service %s/%s has load balancer class %s instead of %s. Delete the service to "+
+				"recreate it with the new class, which replaces its load balancer and address.",
+				current.Namespace, current.Name, currentClass, *desired.Spec.LoadBalancerClass),
+		}
+	}
+	return metav1.Condition{
+		Type:    operatorv1alpha1.EnvoyServiceSyncedConditionType,
+		Status:  metav1.ConditionTrue,
+		Reason:  "EnvoyServiceSynced",
+		Message: "Envoy service matches the contour.",
+	}
+}
+
 // mergeConditions adds or updates matching conditions, and updates
 // the transition time if details of a condition have changed. Returns
 // the updated condition array.
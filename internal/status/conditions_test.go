@@ -112,16 +112,9 @@ func TestComputeContourAvailableCondition(t *testing.T) {
 			},
 		}
 
-		ds := &appsv1.DaemonSet{
-			ObjectMeta: metav1.ObjectMeta{
-				Name: fmt.Sprintf("contour-%d", i+1),
-			},
-			Status: appsv1.DaemonSetStatus{
-				NumberAvailable: tc.dsAvailable,
-			},
-		}
+		envoy := &envoyWorkload{kind: "daemonset", available: tc.dsAvailable}
 
-		actual := computeContourAvailableCondition(deploy, ds)
+		actual := computeContourAvailableCondition(deploy, envoy)
 		if !apiequality.Semantic.DeepEqual(actual.Type, tc.expect.Type) ||
 			!apiequality.Semantic.DeepEqual(actual.Status, tc.expect.Status) {
 			t.Fatalf("%q: expected %#v, got %#v", tc.description, tc.expect, actual)
@@ -129,6 +122,101 @@ func TestComputeContourAvailableCondition(t *testing.T) {
 	}
 }
 
+func TestComputeContourValidCondition(t *testing.T) {
+	testCases := []struct {
+		description   string
+		validationErr error
+		expect        metav1.Condition
+	}{
+		{
+			description: "valid contour",
+			expect: metav1.Condition{
+				Type:   operatorv1alpha1.ContourValidConditionType,
+				Status: metav1.ConditionTrue,
+				Reason: "ContourValid",
+			},
+		},
+		{
+			description:   "invalid contour",
+			validationErr: fmt.Errorf("invalid envoy cipher suite %q", "RC4-SHA"),
+			expect: metav1.Condition{
+				Type:    operatorv1alpha1.ContourValidConditionType,
+				Status:  metav1.ConditionFalse,
+				Reason:  "ValidationFailed",
+				Message: `Contour is invalid: invalid envoy cipher suite "RC4-SHA"`,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		actual := computeContourValidCondition(tc.validationErr)
+		if actual.Type != tc.expect.Type || actual.Status != tc.expect.Status || actual.Reason != tc.expect.Reason {
+			t.Fatalf("%q: expected %#v, got %#v", tc.description, tc.expect, actual)
+		}
+		if tc.expect.Message != "" && actual.Message != tc.expect.Message {
+			t.Fatalf("%q: expected message %q, got %q", tc.description, tc.expect.Message, actual.Message)
+		}
+	}
+}
+
+func TestComputeEnvoyServiceSyncedCondition(t *testing.T) {
+	class := "example.com/lb"
+	otherClass := "example.com/other-lb"
+	lbService := func(class *string) *corev1.Service {
+		return &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "projectcontour", Name: "envoy"},
+			Spec: corev1.ServiceSpec{
+				Type:              corev1.ServiceTypeLoadBalancer,
+				LoadBalancerClass: class,
+			},
+		}
+	}
+
+	testCases := []struct {
+		description string
+		current     *corev1.Service
+		desired     *corev1.Service
+		expect      metav1.ConditionStatus
+	}{
+		{
+			description: "envoy service not found",
+			desired:     lbService(&class),
+			expect:      metav1.ConditionTrue,
+		},
+		{
+			description: "load balancer class unchanged",
+			current:     lbService(&class),
+			desired:     lbService(&class),
+			expect:      metav1.ConditionTrue,
+		},
+		{
+			description: "load balancer class set by another controller",
+			current:     lbService(&otherClass),
+			desired:     lbService(nil),
+			expect:      metav1.ConditionTrue,
+		},
+		{
+			description: "load balancer class changed",
+			current:     lbService(&otherClass),
+			desired:     lbService(&class),
+			expect:      metav1.ConditionFalse,
+		},
+		{
+			description: "load balancer class added",
+			current:     lbService(nil),
+			desired:     lbService(&class),
+			expect:      metav1.ConditionFalse,
+		},
+	}
+
+	for _, tc := range testCases {
+		actual := computeEnvoyServiceSyncedCondition(tc.current, tc.desired)
+		if actual.Type != operatorv1alpha1.EnvoyServiceSyncedConditionType || actual.Status != tc.expect {
+			t.Fatalf("%q: expected status %q, got %#v", tc.description, tc.expect, actual)
+		}
+	}
+}
+
 func TestContourConditionChanged(t *testing.T) {
 	testCases := []struct {
 		description string
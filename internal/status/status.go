@@ -22,16 +22,20 @@ import (
 	"github.com/projectcontour/contour-operator/internal/equality"
 	objds "github.com/projectcontour/contour-operator/internal/objects/daemonset"
 	objdeploy "github.com/projectcontour/contour-operator/internal/objects/deployment"
+	objsvc "github.com/projectcontour/contour-operator/internal/objects/service"
 	retryable "github.com/projectcontour/contour-operator/internal/retryableerror"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 // syncContourStatus computes the current status of contour and updates status upon
-// any changes since last sync.
-func SyncContour(ctx context.Context, cli client.Client, contour *operatorv1alpha1.Contour) error {
+// any changes since last sync. validationErr is the error returned by validating
+// contour, if any, and is surfaced through the Valid condition.
+func SyncContour(ctx context.Context, cli client.Client, contour *operatorv1alpha1.Contour, validationErr error) error {
 	var err error
 	var errs []error
 
@@ -55,16 +59,29 @@ func SyncContour(ctx context.Context, cli client.Client, contour *operatorv1alph
 		errs = append(errs, fmt.Errorf("failed to get deployment for contour %s/%s status: %w", latest.Namespace, latest.Name, err))
 	} else {
 		updated.Status.AvailableContours = deploy.Status.AvailableReplicas
+		updated.Status.Replicas = deploy.Status.Replicas
 	}
-	ds, err := objds.CurrentDaemonSet(ctx, cli, latest)
+	// Publish the selector for the scale subresource even before the
+	// deployment exists.
+	updated.Status.Selector = scaleSelector(latest)
+	envoy, err := currentEnvoyWorkload(ctx, cli, latest)
 	if err != nil {
-		errs = append(errs, fmt.Errorf("failed to get daemonset for contour %s/%s status: %w", latest.Namespace, latest.Name, err))
+		errs = append(errs, fmt.Errorf("failed to get envoy %s for contour %s/%s status: %w",
+			strings.ToLower(string(latest.EnvoyWorkloadType())), latest.Namespace, latest.Name, err))
 	} else {
-		updated.Status.AvailableEnvoys = ds.Status.NumberAvailable
+		updated.Status.AvailableEnvoys = envoy.available
+	}
+
+	svc, err := objsvc.CurrentEnvoyService(ctx, cli, latest)
+	if err != nil && !errors.IsNotFound(err) {
+		errs = append(errs, fmt.Errorf("failed to get envoy service for contour %s/%s status: %w", latest.Namespace, latest.Name, err))
+	} else {
+		updated.Status.Hostname = envoyHostname(latest, svc)
 	}
 
 	updated.Status.Conditions = mergeConditions(updated.Status.Conditions,
-		computeContourAvailableCondition(deploy, ds))
+		computeContourAvailableCondition(deploy, envoy), computeContourValidCondition(validationErr),
+		computeEnvoyServiceSyncedCondition(svc, objsvc.DesiredEnvoyService(latest)))
 
 	if equality.ContourStatusChanged(latest.Status, updated.Status) {
 		if err := cli.Status().Update(ctx, updated); err != nil {
@@ -74,7 +91,7 @@ func SyncContour(ctx context.Context, cli client.Client, contour *operatorv1alph
 				return retryable.NewMaybeRetryableAggregate(errs)
 			case strings.Contains(err.Error(), "the object has been modified"):
 				// Retry if the object was modified during status sync.
-				if err := SyncContour(ctx, cli, updated); err != nil {
+				if err := SyncContour(ctx, cli, updated, validationErr); err != nil {
 					errs = append(errs, fmt.Errorf("failed to update contour %s/%s status: %w", latest.Namespace,
 						latest.Name, err))
 				}
@@ -87,3 +104,50 @@ func SyncContour(ctx context.Context, cli client.Client, contour *operatorv1alph
 
 	return retryable.NewMaybeRetryableAggregate(errs)
 }
+
+// currentEnvoyWorkload returns the availability of the workload running Envoy
+// for contour.
+func currentEnvoyWorkload(ctx context.Context, cli client.Client, contour *operatorv1alpha1.Contour) (*envoyWorkload, error) {
+	if contour.EnvoyWorkloadType() == operatorv1alpha1.DeploymentEnvoyWorkload {
+		deploy, err := objds.CurrentEnvoyDeployment(ctx, cli, contour)
+		if err != nil {
+			return nil, err
+		}
+		return &envoyWorkload{kind: "deployment", available: deploy.Status.AvailableReplicas}, nil
+	}
+	ds, err := objds.CurrentDaemonSet(ctx, cli, contour)
+	if err != nil {
+		return nil, err
+	}
+	return &envoyWorkload{kind: "daemonset", available: ds.Status.NumberAvailable}, nil
+}
+
+// envoyHostname returns the hostname published for the Envoy Service of contour,
+// or an empty string if the hostname is unspecified or the load balancer of svc
+// has not been provisioned. Envoy pods bound to node ports publish the hostname
+// without a Service.
+func envoyHostname(contour *operatorv1alpha1.Contour, svc *corev1.Service) string {
+	if contour.Spec.NetworkPublishing.Envoy.Hostname == nil {
+		return ""
+	}
+	if contour.EnvoyNetworkType() != operatorv1alpha1.ClusterIPEnvoyNetwork {
+		return *contour.Spec.NetworkPublishing.Envoy.Hostname
+	}
+	if svc == nil {
+		return ""
+	}
+	if svc.Spec.Type == corev1.ServiceTypeLoadBalancer && len(svc.Status.LoadBalancer.Ingress) == 0 {
+		return ""
+	}
+	return *contour.Spec.NetworkPublishing.Envoy.Hostname
+}
+
+// scaleSelector returns the selector published for the scale subresource of
+// contour. Pod selectors are namespaced, so no selector is published when the
+// Contour deployment resides outside the namespace of contour.
+func scaleSelector(contour *operatorv1alpha1.Contour) string {
+	if contour.Namespace != contour.Spec.Namespace.Name {
+		return ""
+	}
+	return metav1.FormatLabelSelector(objdeploy.ContourDeploymentPodSelector())
+}
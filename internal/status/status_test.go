@@ -0,0 +1,116 @@
+// Copyright Project Contour Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package status
+
+import (
+	"testing"
+
+	operatorv1alpha1 "github.com/projectcontour/contour-operator/api/v1alpha1"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/pointer"
+)
+
+func TestEnvoyHostname(t *testing.T) {
+	hostname := "ingress.example.com"
+	provisioned := corev1.LoadBalancerStatus{
+		Ingress: []corev1.LoadBalancerIngress{{IP: "1.2.3.4"}},
+	}
+
+	testCases := []struct {
+		description string
+		hostname    *string
+		networkType operatorv1alpha1.EnvoyNetworkType
+		svc         *corev1.Service
+		expected    string
+	}{
+		{
+			description: "hostname unspecified",
+			svc: &corev1.Service{
+				Spec:   corev1.ServiceSpec{Type: corev1.ServiceTypeLoadBalancer},
+				Status: corev1.ServiceStatus{LoadBalancer: provisioned},
+			},
+		},
+		{
+			description: "envoy service not found",
+			hostname:    pointer.String(hostname),
+		},
+		{
+			description: "load balancer not provisioned",
+			hostname:    pointer.String(hostname),
+			svc: &corev1.Service{
+				Spec: corev1.ServiceSpec{Type: corev1.ServiceTypeLoadBalancer},
+			},
+		},
+		{
+			description: "load balancer provisioned",
+			hostname:    pointer.String(hostname),
+			svc: &corev1.Service{
+				Spec:   corev1.ServiceSpec{Type: corev1.ServiceTypeLoadBalancer},
+				Status: corev1.ServiceStatus{LoadBalancer: provisioned},
+			},
+			expected: hostname,
+		},
+		{
+			description: "nodeport service",
+			hostname:    pointer.String(hostname),
+			svc: &corev1.Service{
+				Spec: corev1.ServiceSpec{Type: corev1.ServiceTypeNodePort},
+			},
+			expected: hostname,
+		},
+		{
+			description: "envoy bound to host ports",
+			hostname:    pointer.String(hostname),
+			networkType: operatorv1alpha1.HostPortsEnvoyNetwork,
+			expected:    hostname,
+		},
+	}
+
+	for _, tc := range testCases {
+		cntr := &operatorv1alpha1.Contour{}
+		cntr.Spec.NetworkPublishing.Envoy.Hostname = tc.hostname
+		cntr.Spec.Envoy = &operatorv1alpha1.EnvoySettings{NetworkType: tc.networkType}
+		if actual := envoyHostname(cntr, tc.svc); actual != tc.expected {
+			t.Errorf("%s: expected hostname %q, got %q", tc.description, tc.expected, actual)
+		}
+	}
+}
+
+func TestScaleSelector(t *testing.T) {
+	testCases := []struct {
+		description string
+		specNs      string
+		expected    string
+	}{
+		{
+			description: "deployment in the contour namespace",
+			specNs:      "projectcontour",
+			expected:    "app=contour",
+		},
+		{
+			description: "deployment outside the contour namespace",
+			specNs:      "contour-managed",
+		},
+	}
+
+	for _, tc := range testCases {
+		contour := &operatorv1alpha1.Contour{}
+		contour.Namespace = "projectcontour"
+		contour.Spec.Namespace.Name = tc.specNs
+		if actual := scaleSelector(contour); actual != tc.expected {
+			t.Errorf("%s: expected selector %q, got %q", tc.description, tc.expected, actual)
+		}
+	}
+}
@@ -17,13 +17,48 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"net/url"
+	"strings"
+	"time"
+	"unicode"
 
 	operatorv1alpha1 "github.com/projectcontour/contour-operator/api/v1alpha1"
+	objutil "github.com/projectcontour/contour-operator/internal/objects"
 	objcontour "github.com/projectcontour/contour-operator/internal/objects/contour"
+	objds "github.com/projectcontour/contour-operator/internal/objects/daemonset"
+	objdeploy "github.com/projectcontour/contour-operator/internal/objects/deployment"
+	objsvc "github.com/projectcontour/contour-operator/internal/objects/service"
 	"github.com/projectcontour/contour-operator/pkg/slice"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// maxClientIPAffinitySeconds is the maximum client IP session affinity
+// timeout allowed by the Kubernetes API server.
+const maxClientIPAffinitySeconds = int32(86400)
+
+// supportedCipherSuites are the TLS 1.2 cipher suites supported by Contour.
+var supportedCipherSuites = []string{
+	"[ECDHE-ECDSA-AES128-GCM-SHA256|ECDHE-ECDSA-CHACHA20-POLY1305]",
+	"[ECDHE-RSA-AES128-GCM-SHA256|ECDHE-RSA-CHACHA20-POLY1305]",
+	"ECDHE-ECDSA-AES128-GCM-SHA256",
+	"ECDHE-RSA-AES128-GCM-SHA256",
+	"ECDHE-ECDSA-AES128-SHA",
+	"ECDHE-RSA-AES128-SHA",
+	"AES128-GCM-SHA256",
+	"AES128-SHA",
+	"ECDHE-ECDSA-AES256-GCM-SHA384",
+	"ECDHE-RSA-AES256-GCM-SHA384",
+	"ECDHE-ECDSA-AES256-SHA",
+	"ECDHE-RSA-AES256-SHA",
+	"AES256-GCM-SHA384",
+	"AES256-SHA",
+}
+
 // Contour returns true if contour is valid.
 func Contour(ctx context.Context, cli client.Client, contour *operatorv1alpha1.Contour) error {
 	// TODO [danehans]: Remove when https://github.com/projectcontour/contour-operator/issues/18 is fixed.
@@ -40,6 +75,110 @@ func Contour(ctx context.Context, cli client.Client, contour *operatorv1alpha1.C
 		return err
 	}
 
+	if err := ServicePorts(contour); err != nil {
+		return err
+	}
+
+	if err := ExtraPorts(contour); err != nil {
+		return err
+	}
+
+	if err := IPFamilies(contour); err != nil {
+		return err
+	}
+
+	if err := Hostname(contour); err != nil {
+		return err
+	}
+
+	if err := SessionAffinity(contour); err != nil {
+		return err
+	}
+
+	if err := ProxyProtocol(contour); err != nil {
+		return err
+	}
+
+	if err := EnvoyWorkload(contour); err != nil {
+		return err
+	}
+
+	if err := EnvoyHostNetwork(contour); err != nil {
+		return err
+	}
+
+	if err := Images(contour); err != nil {
+		return err
+	}
+
+	if err := PodDisruptionBudgets(contour); err != nil {
+		return err
+	}
+
+	if err := ContourStrategy(contour); err != nil {
+		return err
+	}
+
+	if err := EnvoyShutdown(contour); err != nil {
+		return err
+	}
+
+	if err := EnvoyConcurrency(contour); err != nil {
+		return err
+	}
+
+	if err := EnvoyBootstrap(contour); err != nil {
+		return err
+	}
+
+	if err := RootNamespaces(contour); err != nil {
+		return err
+	}
+
+	if err := IngressClass(contour); err != nil {
+		return err
+	}
+
+	if err := EnvoyTLS(contour); err != nil {
+		return err
+	}
+
+	if err := EnvoyHeadersPolicy(contour); err != nil {
+		return err
+	}
+
+	if err := EnvoyRateLimitService(contour); err != nil {
+		return err
+	}
+
+	if err := EnvoyAccessLog(contour); err != nil {
+		return err
+	}
+
+	if err := EnvoyTimeouts(contour); err != nil {
+		return err
+	}
+
+	if err := DNS(contour); err != nil {
+		return err
+	}
+
+	if err := ExtraVolumes(contour); err != nil {
+		return err
+	}
+
+	if err := ProxyConfig(contour); err != nil {
+		return err
+	}
+
+	if err := ExtraEnv(contour); err != nil {
+		return err
+	}
+
+	if err := ExtraContainers(contour); err != nil {
+		return err
+	}
+
 	if contour.Spec.NetworkPublishing.Envoy.Type == operatorv1alpha1.NodePortServicePublishingType {
 		if err := NodePorts(contour); err != nil {
 			return err
@@ -87,6 +226,676 @@ func ContainerPorts(contour *operatorv1alpha1.Contour) error {
 	return fmt.Errorf("http and https container ports are unspecified")
 }
 
+// ServicePorts validates the Envoy Service ports of contour, returning an
+// error if the service ports do not meet the API specification.
+func ServicePorts(contour *operatorv1alpha1.Contour) error {
+	var numsFound []int32
+	var namesFound []string
+	for _, port := range contour.Spec.NetworkPublishing.Envoy.ServicePorts {
+		if port.Name != "http" && port.Name != "https" {
+			return fmt.Errorf("invalid service port name %q; only \"http\" and \"https\" are supported", port.Name)
+		}
+		if slice.ContainsString(namesFound, port.Name) {
+			return fmt.Errorf("duplicate service port name %q", port.Name)
+		}
+		namesFound = append(namesFound, port.Name)
+		if slice.ContainsInt32(numsFound, port.PortNumber) {
+			return fmt.Errorf("duplicate service port number %d", port.PortNumber)
+		}
+		numsFound = append(numsFound, port.PortNumber)
+	}
+	return nil
+}
+
+// ExtraPorts validates the extra Envoy ports of contour, returning an error
+// if the extra ports are invalid or conflict with the http and https ports.
+func ExtraPorts(contour *operatorv1alpha1.Contour) error {
+	var namesFound []string
+	var containerNumsFound []int32
+	for _, port := range contour.Spec.NetworkPublishing.Envoy.ContainerPorts {
+		containerNumsFound = append(containerNumsFound, port.PortNumber)
+	}
+	svcNumsFound := envoyServicePortNumbers(contour)
+	for _, port := range contour.Spec.NetworkPublishing.Envoy.ExtraPorts {
+		if port.Name == "http" || port.Name == "https" {
+			return fmt.Errorf("invalid extra port name %q; \"http\" and \"https\" are reserved", port.Name)
+		}
+		if errs := validation.IsValidPortName(port.Name); len(errs) > 0 {
+			return fmt.Errorf("invalid extra port name %q: %s", port.Name, strings.Join(errs, ", "))
+		}
+		if slice.ContainsString(namesFound, port.Name) {
+			return fmt.Errorf("duplicate extra port name %q", port.Name)
+		}
+		namesFound = append(namesFound, port.Name)
+		if slice.ContainsInt32(svcNumsFound, port.PortNumber) {
+			return fmt.Errorf("duplicate service port number %d", port.PortNumber)
+		}
+		svcNumsFound = append(svcNumsFound, port.PortNumber)
+		if slice.ContainsInt32(containerNumsFound, port.ContainerPortNumber) {
+			return fmt.Errorf("duplicate container port number %d", port.ContainerPortNumber)
+		}
+		containerNumsFound = append(containerNumsFound, port.ContainerPortNumber)
+	}
+	return nil
+}
+
+// envoyServicePortNumbers returns the http and https port numbers of the
+// Envoy Service of contour.
+func envoyServicePortNumbers(contour *operatorv1alpha1.Contour) []int32 {
+	nums := map[string]int32{"http": objsvc.EnvoyServiceHTTPPort, "https": objsvc.EnvoyServiceHTTPSPort}
+	for _, port := range contour.Spec.NetworkPublishing.Envoy.ServicePorts {
+		nums[port.Name] = port.PortNumber
+	}
+	return []int32{nums["http"], nums["https"]}
+}
+
+// Hostname validates the Envoy hostname of contour, returning an error if
+// the hostname is not a valid, optionally wildcard, DNS subdomain.
+func Hostname(contour *operatorv1alpha1.Contour) error {
+	hostname := contour.Spec.NetworkPublishing.Envoy.Hostname
+	if hostname == nil {
+		return nil
+	}
+	errs := validation.IsDNS1123Subdomain(*hostname)
+	if strings.HasPrefix(*hostname, "*.") {
+		errs = validation.IsWildcardDNS1123Subdomain(*hostname)
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid hostname %q: %s", *hostname, strings.Join(errs, ", "))
+	}
+	return nil
+}
+
+// SessionAffinity validates the Envoy session affinity of contour, returning
+// an error if sessionAffinityConfig is set without ClientIP affinity or the
+// client IP timeout is out of range.
+func SessionAffinity(contour *operatorv1alpha1.Contour) error {
+	cfg := contour.Spec.NetworkPublishing.Envoy.SessionAffinityConfig
+	if cfg == nil {
+		return nil
+	}
+	if contour.Spec.NetworkPublishing.Envoy.SessionAffinity != corev1.ServiceAffinityClientIP {
+		return fmt.Errorf("session affinity config requires session affinity %q", corev1.ServiceAffinityClientIP)
+	}
+	if cfg.ClientIP != nil && cfg.ClientIP.TimeoutSeconds != nil {
+		timeout := *cfg.ClientIP.TimeoutSeconds
+		if timeout <= 0 || timeout > maxClientIPAffinitySeconds {
+			return fmt.Errorf("invalid session affinity timeout %d; must be between 1 and %d",
+				timeout, maxClientIPAffinitySeconds)
+		}
+	}
+	return nil
+}
+
+// ProxyProtocol validates the PROXY protocol setting of contour, returning
+// an error if the PROXY protocol is enabled for a publishing type or load
+// balancer provider that never sends the PROXY protocol header, since Envoy
+// would then reject every connection.
+func ProxyProtocol(contour *operatorv1alpha1.Contour) error {
+	envoy := contour.Spec.NetworkPublishing.Envoy
+	if envoy.LoadBalancer.ProxyProtocol == nil || !*envoy.LoadBalancer.ProxyProtocol {
+		return nil
+	}
+	if envoy.Type != operatorv1alpha1.LoadBalancerServicePublishingType {
+		return fmt.Errorf("proxy protocol requires the %q publishing type; %q services don't send the PROXY protocol header",
+			operatorv1alpha1.LoadBalancerServicePublishingType, envoy.Type)
+	}
+	// A load balancer class hands the Service to a custom controller
+	// which may well speak the PROXY protocol, so trust the user.
+	if envoy.LoadBalancer.LoadBalancerClass != nil {
+		return nil
+	}
+	switch provider := envoy.LoadBalancer.ProviderParameters.Type; provider {
+	case operatorv1alpha1.GCPLoadBalancerProvider, operatorv1alpha1.MetalLBLoadBalancerProvider:
+		return fmt.Errorf("proxy protocol is not supported by %s load balancers; disable proxyProtocol or set a loadBalancerClass "+
+			"for a load balancer implementation that sends the PROXY protocol header", provider)
+	}
+	return nil
+}
+
+// EnvoyWorkload validates the Envoy workload settings of contour, returning
+// an error if replicas or autoscaling are set for a workload type that doesn't
+// use them, or if the autoscaling replica limits are inconsistent.
+func EnvoyWorkload(contour *operatorv1alpha1.Contour) error {
+	envoy := contour.Spec.Envoy
+	if envoy == nil {
+		return nil
+	}
+	deployment := contour.EnvoyWorkloadType() == operatorv1alpha1.DeploymentEnvoyWorkload
+	if envoy.Replicas != nil && !deployment {
+		return fmt.Errorf("envoy replicas require workload type %q", operatorv1alpha1.DeploymentEnvoyWorkload)
+	}
+	if strategy := envoy.UpdateStrategy; strategy != nil {
+		if deployment {
+			return fmt.Errorf("envoy update strategy requires workload type %q", operatorv1alpha1.DaemonSetEnvoyWorkload)
+		}
+		if strategy.Type == appsv1.OnDeleteDaemonSetStrategyType && strategy.RollingUpdate != nil {
+			return fmt.Errorf("envoy rolling update settings require update strategy type %q", appsv1.RollingUpdateDaemonSetStrategyType)
+		}
+		if rolling := strategy.RollingUpdate; rolling != nil {
+			if isZero(rolling.MaxUnavailable) && (rolling.MaxSurge == nil || isZero(rolling.MaxSurge)) {
+				return fmt.Errorf("invalid envoy rolling update; max unavailable and max surge can't both be zero")
+			}
+			if rolling.MaxSurge != nil && !isZero(rolling.MaxSurge) &&
+				contour.EnvoyNetworkType() != operatorv1alpha1.ClusterIPEnvoyNetwork {
+				return fmt.Errorf("envoy max surge is not supported for network type %q; surge pods can't bind the node ports",
+					contour.EnvoyNetworkType())
+			}
+		}
+	}
+	if autoscaling := envoy.Autoscaling; autoscaling != nil {
+		if !deployment {
+			return fmt.Errorf("envoy autoscaling requires workload type %q", operatorv1alpha1.DeploymentEnvoyWorkload)
+		}
+		if envoy.Replicas != nil {
+			return fmt.Errorf("envoy replicas and autoscaling are mutually exclusive")
+		}
+		if autoscaling.MinReplicas != nil && *autoscaling.MinReplicas > autoscaling.MaxReplicas {
+			return fmt.Errorf("invalid envoy autoscaling; min replicas %d exceed max replicas %d",
+				*autoscaling.MinReplicas, autoscaling.MaxReplicas)
+		}
+	}
+	return nil
+}
+
+// EnvoyHostNetwork validates the ports of contour when Envoy uses the host
+// network, returning an error if Envoy would bind a privileged port of the
+// node without a security context granting it the privileges to do so.
+func EnvoyHostNetwork(contour *operatorv1alpha1.Contour) error {
+	if contour.EnvoyNetworkType() != operatorv1alpha1.HostNetworkEnvoyNetwork {
+		return nil
+	}
+	if envoy := contour.Spec.Envoy; envoy != nil && (envoy.SecurityContext != nil || envoy.PodSecurityContext != nil) {
+		return nil
+	}
+	for _, port := range contour.Spec.NetworkPublishing.Envoy.ContainerPorts {
+		if port.PortNumber < 1024 {
+			return fmt.Errorf("envoy container port %s %d is privileged; network type %q requires an envoy security context "+
+				"allowing Envoy to bind it", port.Name, port.PortNumber, operatorv1alpha1.HostNetworkEnvoyNetwork)
+		}
+	}
+	for _, port := range contour.Spec.NetworkPublishing.Envoy.ExtraPorts {
+		if port.ContainerPortNumber < 1024 {
+			return fmt.Errorf("envoy extra port %s %d is privileged; network type %q requires an envoy security context "+
+				"allowing Envoy to bind it", port.Name, port.ContainerPortNumber, operatorv1alpha1.HostNetworkEnvoyNetwork)
+		}
+	}
+	return nil
+}
+
+// ContourStrategy validates the update strategy of the Contour Deployment of
+// contour, returning an error if rolling update settings are set for the
+// Recreate strategy or would never make progress.
+func ContourStrategy(contour *operatorv1alpha1.Contour) error {
+	if contour.Spec.Contour == nil || contour.Spec.Contour.Strategy == nil {
+		return nil
+	}
+	strategy := contour.Spec.Contour.Strategy
+	if strategy.Type == appsv1.RecreateDeploymentStrategyType && strategy.RollingUpdate != nil {
+		return fmt.Errorf("contour rolling update settings require strategy type %q", appsv1.RollingUpdateDeploymentStrategyType)
+	}
+	// Unset fields are defaulted to a non-zero value.
+	if rolling := strategy.RollingUpdate; rolling != nil && isZero(rolling.MaxSurge) && isZero(rolling.MaxUnavailable) {
+		return fmt.Errorf("invalid contour rolling update; max surge and max unavailable can't both be zero")
+	}
+	return nil
+}
+
+// EnvoyBootstrap validates the Envoy bootstrap settings of contour, returning
+// an error if the xDS address is neither a DNS subdomain nor an IP address.
+func EnvoyBootstrap(contour *operatorv1alpha1.Contour) error {
+	if contour.Spec.Envoy == nil || contour.Spec.Envoy.Bootstrap == nil {
+		return nil
+	}
+	addr := contour.Spec.Envoy.Bootstrap.XDSAddress
+	if addr == "" || net.ParseIP(addr) != nil {
+		return nil
+	}
+	if errs := validation.IsDNS1123Subdomain(addr); len(errs) > 0 {
+		return fmt.Errorf("invalid envoy xds address %q: %s", addr, strings.Join(errs, ", "))
+	}
+	return nil
+}
+
+// RootNamespaces validates the root namespaces of contour, returning an error
+// if a namespace is not a valid namespace name.
+func RootNamespaces(contour *operatorv1alpha1.Contour) error {
+	for _, ns := range contour.Spec.RootNamespaces {
+		if errs := validation.IsDNS1123Label(ns); len(errs) > 0 {
+			return fmt.Errorf("invalid root namespace %q: %s", ns, strings.Join(errs, ", "))
+		}
+	}
+	return nil
+}
+
+// IngressClass validates the IngressClass settings of contour, returning an
+// error if createIngressClass is set without ingressClassName.
+func IngressClass(contour *operatorv1alpha1.Contour) error {
+	if contour.Spec.CreateIngressClass && contour.Spec.IngressClassName == nil {
+		return fmt.Errorf("createIngressClass requires ingressClassName")
+	}
+	return nil
+}
+
+// EnvoyTLS validates the Envoy TLS settings of contour, returning an error
+// if a cipher suite is not supported by Contour.
+func EnvoyTLS(contour *operatorv1alpha1.Contour) error {
+	if contour.Spec.Envoy == nil || contour.Spec.Envoy.TLS == nil {
+		return nil
+	}
+	for _, cipher := range contour.Spec.Envoy.TLS.CipherSuites {
+		if !slice.ContainsString(supportedCipherSuites, cipher) {
+			return fmt.Errorf("invalid envoy tls cipher suite %q; must be one of %s", cipher, strings.Join(supportedCipherSuites, ", "))
+		}
+	}
+	return nil
+}
+
+// EnvoyHeadersPolicy validates the global headers policy of contour,
+// returning an error if a header name is not a valid HTTP header name.
+func EnvoyHeadersPolicy(contour *operatorv1alpha1.Contour) error {
+	if contour.Spec.Envoy == nil || contour.Spec.Envoy.HeadersPolicy == nil {
+		return nil
+	}
+	for _, policy := range []*operatorv1alpha1.HeadersPolicy{
+		contour.Spec.Envoy.HeadersPolicy.Request,
+		contour.Spec.Envoy.HeadersPolicy.Response,
+	} {
+		if policy == nil {
+			continue
+		}
+		names := append([]string{}, policy.Remove...)
+		for name := range policy.Set {
+			names = append(names, name)
+		}
+		for _, name := range names {
+			if errs := validation.IsHTTPHeaderName(name); len(errs) > 0 {
+				return fmt.Errorf("invalid envoy headers policy header %q: %s", name, strings.Join(errs, ", "))
+			}
+		}
+	}
+	return nil
+}
+
+// EnvoyRateLimitService validates the rate limit service settings of contour,
+// returning an error if the ExtensionService reference is not a valid
+// namespace/name or the domain contains whitespace or non-printable characters.
+func EnvoyRateLimitService(contour *operatorv1alpha1.Contour) error {
+	if contour.Spec.Envoy == nil || contour.Spec.Envoy.RateLimitService == nil {
+		return nil
+	}
+	rls := contour.Spec.Envoy.RateLimitService
+	if errs := validation.IsDNS1123Subdomain(rls.ExtensionService.Name); len(errs) > 0 {
+		return fmt.Errorf("invalid rate limit extension service name %q: %s",
+			rls.ExtensionService.Name, strings.Join(errs, ", "))
+	}
+	if ns := rls.ExtensionService.Namespace; ns != "" {
+		if errs := validation.IsDNS1123Label(ns); len(errs) > 0 {
+			return fmt.Errorf("invalid rate limit extension service namespace %q: %s", ns, strings.Join(errs, ", "))
+		}
+	}
+	for _, r := range rls.Domain {
+		if r > unicode.MaxASCII || !unicode.IsPrint(r) || unicode.IsSpace(r) {
+			return fmt.Errorf("invalid rate limit domain %q; must consist of printable ASCII characters "+
+				"other than whitespace", rls.Domain)
+		}
+	}
+	return nil
+}
+
+// EnvoyAccessLog validates the Envoy access log settings of contour,
+// returning an error if JSON fields are set without the JSON format or if a
+// JSON field is empty.
+func EnvoyAccessLog(contour *operatorv1alpha1.Contour) error {
+	if contour.Spec.Envoy == nil || contour.Spec.Envoy.AccessLog == nil {
+		return nil
+	}
+	accessLog := contour.Spec.Envoy.AccessLog
+	if len(accessLog.JSONFields) > 0 && accessLog.Format != operatorv1alpha1.JSONAccessLogFormat {
+		return fmt.Errorf("invalid envoy access log; jsonFields requires format %q", operatorv1alpha1.JSONAccessLogFormat)
+	}
+	for _, field := range accessLog.JSONFields {
+		if strings.TrimSpace(field) == "" {
+			return fmt.Errorf("invalid envoy access log; jsonFields must not contain empty fields")
+		}
+	}
+	return nil
+}
+
+// EnvoyTimeouts validates the Envoy timeouts of contour, returning an error
+// if a timeout is neither a non-negative duration nor "infinity".
+func EnvoyTimeouts(contour *operatorv1alpha1.Contour) error {
+	if contour.Spec.Envoy == nil || contour.Spec.Envoy.Timeouts == nil {
+		return nil
+	}
+	timeouts := contour.Spec.Envoy.Timeouts
+	for _, t := range []struct{ name, value string }{
+		{"requestTimeout", timeouts.RequestTimeout},
+		{"connectionIdleTimeout", timeouts.ConnectionIdleTimeout},
+		{"streamIdleTimeout", timeouts.StreamIdleTimeout},
+		{"maxConnectionDuration", timeouts.MaxConnectionDuration},
+		{"connectionShutdownGracePeriod", timeouts.ConnectionShutdownGracePeriod},
+	} {
+		if t.value == "" || t.value == "infinity" || t.value == "infinite" {
+			continue
+		}
+		d, err := time.ParseDuration(t.value)
+		if err != nil || d < 0 {
+			return fmt.Errorf("invalid envoy %s %q; must be a non-negative duration or \"infinity\"", t.name, t.value)
+		}
+	}
+	return nil
+}
+
+// EnvoyConcurrency validates the Envoy concurrency settings of contour,
+// returning an error unless exactly one of workers and fromCPULimit is set,
+// or if fromCPULimit is set without an Envoy CPU limit.
+func EnvoyConcurrency(contour *operatorv1alpha1.Contour) error {
+	if contour.Spec.Envoy == nil || contour.Spec.Envoy.Concurrency == nil {
+		return nil
+	}
+	concurrency := contour.Spec.Envoy.Concurrency
+	if (concurrency.Workers != nil) == concurrency.FromCPULimit {
+		return fmt.Errorf("invalid envoy concurrency; exactly one of workers and fromCPULimit must be set")
+	}
+	if concurrency.FromCPULimit {
+		if contour.Spec.Resources == nil || contour.Spec.Resources.Envoy.Limits.Cpu().IsZero() {
+			return fmt.Errorf("envoy concurrency from cpu limit requires an envoy cpu limit")
+		}
+	}
+	return nil
+}
+
+// EnvoyShutdown validates the shutdown settings of contour's Envoy pods,
+// returning an error if a delay is negative, the check interval isn't
+// positive, or the delays exceed the termination grace period.
+func EnvoyShutdown(contour *operatorv1alpha1.Contour) error {
+	envoy := contour.Spec.Envoy
+	if envoy == nil || envoy.Shutdown == nil {
+		return nil
+	}
+	shutdown := envoy.Shutdown
+	var delay time.Duration
+	if shutdown.DrainDelay != nil {
+		if shutdown.DrainDelay.Duration < 0 {
+			return fmt.Errorf("invalid envoy shutdown drain delay %s; must not be negative", shutdown.DrainDelay.Duration)
+		}
+		delay += shutdown.DrainDelay.Duration
+	}
+	if shutdown.CheckDelay != nil {
+		if shutdown.CheckDelay.Duration < 0 {
+			return fmt.Errorf("invalid envoy shutdown check delay %s; must not be negative", shutdown.CheckDelay.Duration)
+		}
+		delay += shutdown.CheckDelay.Duration
+	}
+	if shutdown.CheckInterval != nil && shutdown.CheckInterval.Duration <= 0 {
+		return fmt.Errorf("invalid envoy shutdown check interval %s; must be positive", shutdown.CheckInterval.Duration)
+	}
+	grace := time.Duration(objds.DefaultTerminationGracePeriodSeconds) * time.Second
+	if envoy.TerminationGracePeriodSeconds != nil {
+		grace = time.Duration(*envoy.TerminationGracePeriodSeconds) * time.Second
+	}
+	if delay >= grace {
+		return fmt.Errorf("invalid envoy shutdown; drain and check delays of %s must be less than the termination grace period of %s",
+			delay, grace)
+	}
+	return nil
+}
+
+// DNS validates the DNS settings of contour's pods, returning an error if the
+// "None" DNS policy is used without nameservers.
+func DNS(contour *operatorv1alpha1.Contour) error {
+	if settings := contour.Spec.Contour; settings != nil {
+		if err := dns(settings.DNSPolicy, settings.DNSConfig); err != nil {
+			return fmt.Errorf("invalid contour dns settings: %w", err)
+		}
+	}
+	if settings := contour.Spec.Envoy; settings != nil {
+		if err := dns(settings.DNSPolicy, settings.DNSConfig); err != nil {
+			return fmt.Errorf("invalid envoy dns settings: %w", err)
+		}
+	}
+	return nil
+}
+
+func dns(policy corev1.DNSPolicy, config *corev1.PodDNSConfig) error {
+	if policy == corev1.DNSNone && (config == nil || len(config.Nameservers) == 0) {
+		return fmt.Errorf("dns policy %q requires at least one nameserver in dns config", corev1.DNSNone)
+	}
+	return nil
+}
+
+// ExtraVolumes validates the extra volumes of contour, returning an error if
+// a volume name is duplicated or collides with a volume managed by the
+// operator, or if a volume mount references an unknown volume.
+func ExtraVolumes(contour *operatorv1alpha1.Contour) error {
+	if settings := contour.Spec.Contour; settings != nil {
+		if err := extraVolumes(settings.ExtraVolumes, settings.ExtraVolumeMounts, objdeploy.ReservedVolumeNames()); err != nil {
+			return fmt.Errorf("invalid contour extra volumes: %w", err)
+		}
+	}
+	if settings := contour.Spec.Envoy; settings != nil {
+		if err := extraVolumes(settings.ExtraVolumes, settings.ExtraVolumeMounts, objds.ReservedVolumeNames()); err != nil {
+			return fmt.Errorf("invalid envoy extra volumes: %w", err)
+		}
+	}
+	return nil
+}
+
+func extraVolumes(volumes []corev1.Volume, mounts []corev1.VolumeMount, reserved []string) error {
+	names := map[string]bool{}
+	for _, name := range reserved {
+		names[name] = true
+	}
+	for _, vol := range volumes {
+		if names[vol.Name] {
+			return fmt.Errorf("volume name %q is already in use", vol.Name)
+		}
+		names[vol.Name] = true
+	}
+	for _, mount := range mounts {
+		if !names[mount.Name] {
+			return fmt.Errorf("volume mount %q references an unknown volume", mount.Name)
+		}
+	}
+	return nil
+}
+
+// ProxyConfig validates the proxy configuration of contour, returning an
+// error if a proxy URL isn't an absolute HTTP or HTTPS URL, or if no proxy
+// URL is set.
+func ProxyConfig(contour *operatorv1alpha1.Contour) error {
+	proxy := contour.Spec.ProxyConfig
+	if proxy == nil {
+		return nil
+	}
+	if proxy.HTTPProxy == "" && proxy.HTTPSProxy == "" {
+		return fmt.Errorf("invalid proxy config; at least one of httpProxy and httpsProxy must be set")
+	}
+	for _, u := range []string{proxy.HTTPProxy, proxy.HTTPSProxy} {
+		if u == "" {
+			continue
+		}
+		parsed, err := url.Parse(u)
+		if err != nil {
+			return fmt.Errorf("invalid proxy url %q: %w", u, err)
+		}
+		if (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return fmt.Errorf("invalid proxy url %q; must be an absolute http or https url", u)
+		}
+	}
+	return nil
+}
+
+// ExtraEnv validates the extra environment variables of contour, returning an
+// error if a variable name is empty, duplicated or collides with a variable
+// managed by the operator.
+func ExtraEnv(contour *operatorv1alpha1.Contour) error {
+	if settings := contour.Spec.Contour; settings != nil {
+		reserved := objdeploy.ReservedEnvVarNames()
+		for _, envVar := range objutil.ProxyEnv(contour) {
+			reserved = append(reserved, envVar.Name)
+		}
+		if err := extraEnv(settings.ExtraEnv, reserved); err != nil {
+			return fmt.Errorf("invalid contour extra env: %w", err)
+		}
+	}
+	if settings := contour.Spec.Envoy; settings != nil {
+		if err := extraEnv(settings.ExtraEnv, objds.ReservedEnvVarNames()); err != nil {
+			return fmt.Errorf("invalid envoy extra env: %w", err)
+		}
+	}
+	return nil
+}
+
+func extraEnv(env []corev1.EnvVar, reserved []string) error {
+	names := map[string]bool{}
+	for _, name := range reserved {
+		names[name] = true
+	}
+	for _, envVar := range env {
+		if len(envVar.Name) == 0 {
+			return fmt.Errorf("environment variable name must not be empty")
+		}
+		if names[envVar.Name] {
+			return fmt.Errorf("environment variable %q is already set", envVar.Name)
+		}
+		names[envVar.Name] = true
+	}
+	return nil
+}
+
+// ExtraContainers validates the extra containers and init containers of
+// contour, returning an error if a container name is empty, duplicated or
+// collides with a container managed by the operator, if a container has no
+// image, or if a Contour sidecar binds a port used by Contour.
+func ExtraContainers(contour *operatorv1alpha1.Contour) error {
+	if settings := contour.Spec.Contour; settings != nil {
+		if err := extraContainers(settings.ExtraContainers, settings.ExtraInitContainers, objdeploy.ReservedContainerNames()); err != nil {
+			return fmt.Errorf("invalid contour extra containers: %w", err)
+		}
+		if err := extraContainerPorts(settings.ExtraContainers, objdeploy.ReservedPorts()); err != nil {
+			return fmt.Errorf("invalid contour extra containers: %w", err)
+		}
+	}
+	if settings := contour.Spec.Envoy; settings != nil {
+		if err := extraContainers(settings.ExtraContainers, settings.ExtraInitContainers, objds.ReservedContainerNames()); err != nil {
+			return fmt.Errorf("invalid envoy extra containers: %w", err)
+		}
+	}
+	return nil
+}
+
+func extraContainers(containers, initContainers []corev1.Container, reserved []string) error {
+	names := map[string]bool{}
+	for _, name := range reserved {
+		names[name] = true
+	}
+	// Containers and init containers share a namespace of names.
+	for _, c := range append(append([]corev1.Container{}, containers...), initContainers...) {
+		if len(c.Name) == 0 {
+			return fmt.Errorf("container name must not be empty")
+		}
+		if names[c.Name] {
+			return fmt.Errorf("container name %q is already in use", c.Name)
+		}
+		if len(c.Image) == 0 {
+			return fmt.Errorf("container %q must have an image", c.Name)
+		}
+		names[c.Name] = true
+	}
+	return nil
+}
+
+// extraContainerPorts returns an error if containers bind one of the reserved
+// ports or the same port more than once. Containers of a pod share its
+// network namespace, so such ports would fail to bind.
+func extraContainerPorts(containers []corev1.Container, reserved []int32) error {
+	ports := map[int32]bool{}
+	for _, port := range reserved {
+		ports[port] = true
+	}
+	for _, c := range containers {
+		for _, p := range c.Ports {
+			if ports[p.ContainerPort] {
+				return fmt.Errorf("port %d of container %q is already in use", p.ContainerPort, c.Name)
+			}
+			ports[p.ContainerPort] = true
+		}
+	}
+	return nil
+}
+
+// isZero returns true if v is set to zero pods, either as a number or as a
+// percentage.
+func isZero(v *intstr.IntOrString) bool {
+	if v == nil {
+		return false
+	}
+	if v.Type == intstr.String {
+		return v.StrVal == "0%"
+	}
+	return v.IntVal == 0
+}
+
+// PodDisruptionBudgets validates the PodDisruptionBudget settings of contour,
+// returning an error if both minAvailable and maxUnavailable are set, or if an
+// Envoy PodDisruptionBudget is set for a workload type that doesn't use it.
+func PodDisruptionBudgets(contour *operatorv1alpha1.Contour) error {
+	if contour.Spec.Contour != nil {
+		if err := podDisruptionBudget(contour.Spec.Contour.PodDisruptionBudget); err != nil {
+			return fmt.Errorf("invalid contour pod disruption budget: %w", err)
+		}
+	}
+	if contour.Spec.Envoy != nil && contour.Spec.Envoy.PodDisruptionBudget != nil {
+		if contour.EnvoyWorkloadType() != operatorv1alpha1.DeploymentEnvoyWorkload {
+			return fmt.Errorf("envoy pod disruption budget requires workload type %q", operatorv1alpha1.DeploymentEnvoyWorkload)
+		}
+		if err := podDisruptionBudget(contour.Spec.Envoy.PodDisruptionBudget); err != nil {
+			return fmt.Errorf("invalid envoy pod disruption budget: %w", err)
+		}
+	}
+	return nil
+}
+
+func podDisruptionBudget(pdb *operatorv1alpha1.PodDisruptionBudgetSettings) error {
+	if pdb != nil && pdb.MinAvailable != nil && pdb.MaxUnavailable != nil {
+		return fmt.Errorf("min available and max unavailable are mutually exclusive")
+	}
+	return nil
+}
+
+// Images validates the image overrides of contour, returning an error if
+// an override sets both a tag and a digest.
+func Images(contour *operatorv1alpha1.Contour) error {
+	if override := contour.ContourImageOverride(); override != nil && override.Tag != "" && override.Digest != "" {
+		return fmt.Errorf("invalid contour image; tag and digest are mutually exclusive")
+	}
+	if override := contour.EnvoyImageOverride(); override != nil && override.Tag != "" && override.Digest != "" {
+		return fmt.Errorf("invalid envoy image; tag and digest are mutually exclusive")
+	}
+	return nil
+}
+
+// IPFamilies validates the IP family policy and IP families of contour,
+// returning an error if they do not meet the API specification.
+func IPFamilies(contour *operatorv1alpha1.Contour) error {
+	families := contour.Spec.NetworkPublishing.IPFamilies
+	for i, f := range families {
+		if f != corev1.IPv4Protocol && f != corev1.IPv6Protocol {
+			return fmt.Errorf("invalid ip family %q; only %q and %q are supported", f, corev1.IPv4Protocol, corev1.IPv6Protocol)
+		}
+		if i > 0 && families[0] == f {
+			return fmt.Errorf("duplicate ip family %q", f)
+		}
+	}
+	policy := contour.Spec.NetworkPublishing.IPFamilyPolicy
+	if len(families) > 1 && (policy == nil || *policy == corev1.IPFamilyPolicySingleStack) {
+		return fmt.Errorf("multiple ip families require a dual-stack ip family policy")
+	}
+	return nil
+}
+
 // NodePorts validates nodeports of contour, returning an error if the nodeports
 // do not meet the API specification.
 func NodePorts(contour *operatorv1alpha1.Contour) error {
@@ -141,19 +950,47 @@ func LoadBalancerProvider(contour *operatorv1alpha1.Contour) error {
 	switch contour.Spec.NetworkPublishing.Envoy.LoadBalancer.ProviderParameters.Type {
 	case operatorv1alpha1.AWSLoadBalancerProvider:
 		if contour.Spec.NetworkPublishing.Envoy.LoadBalancer.ProviderParameters.Azure != nil ||
-			contour.Spec.NetworkPublishing.Envoy.LoadBalancer.ProviderParameters.GCP != nil {
+			contour.Spec.NetworkPublishing.Envoy.LoadBalancer.ProviderParameters.GCP != nil ||
+			contour.Spec.NetworkPublishing.Envoy.LoadBalancer.ProviderParameters.MetalLB != nil {
 			return fmt.Errorf("aws provider chosen, other providers parameters should not be specified")
 		}
+		aws := contour.Spec.NetworkPublishing.Envoy.LoadBalancer.ProviderParameters.AWS
+		if aws != nil && aws.Type != operatorv1alpha1.AWSNetworkLoadBalancer && len(aws.AllocationIDs) > 0 {
+			return fmt.Errorf("aws allocation ids are only supported by the %q load balancer type", operatorv1alpha1.AWSNetworkLoadBalancer)
+		}
+		if aws != nil && len(aws.AllocationIDs) > 0 && contour.Spec.NetworkPublishing.Envoy.LoadBalancer.Scope == operatorv1alpha1.InternalLoadBalancer {
+			return fmt.Errorf("aws allocation ids require the %q load balancer scope; elastic ips can't be attached to internal load balancers",
+				operatorv1alpha1.ExternalLoadBalancer)
+		}
 	case operatorv1alpha1.AzureLoadBalancerProvider:
 		if contour.Spec.NetworkPublishing.Envoy.LoadBalancer.ProviderParameters.AWS != nil ||
-			contour.Spec.NetworkPublishing.Envoy.LoadBalancer.ProviderParameters.GCP != nil {
+			contour.Spec.NetworkPublishing.Envoy.LoadBalancer.ProviderParameters.GCP != nil ||
+			contour.Spec.NetworkPublishing.Envoy.LoadBalancer.ProviderParameters.MetalLB != nil {
 			return fmt.Errorf("azure provider chosen, other providers parameters should not be specified")
 		}
 	case operatorv1alpha1.GCPLoadBalancerProvider:
 		if contour.Spec.NetworkPublishing.Envoy.LoadBalancer.ProviderParameters.AWS != nil ||
-			contour.Spec.NetworkPublishing.Envoy.LoadBalancer.ProviderParameters.Azure != nil {
+			contour.Spec.NetworkPublishing.Envoy.LoadBalancer.ProviderParameters.Azure != nil ||
+			contour.Spec.NetworkPublishing.Envoy.LoadBalancer.ProviderParameters.MetalLB != nil {
 			return fmt.Errorf("gcp provider chosen, other providers parameters should not be specified")
 		}
+		if gcp := contour.Spec.NetworkPublishing.Envoy.LoadBalancer.ProviderParameters.GCP; gcp != nil && gcp.NetworkEndpointGroups != nil {
+			svcPorts := envoyServicePortNumbers(contour)
+			for _, port := range contour.Spec.NetworkPublishing.Envoy.ExtraPorts {
+				svcPorts = append(svcPorts, port.PortNumber)
+			}
+			for _, port := range gcp.NetworkEndpointGroups.ExposedPorts {
+				if !slice.ContainsInt32(svcPorts, port) {
+					return fmt.Errorf("invalid gcp neg exposed port %d; not an envoy service port", port)
+				}
+			}
+		}
+	case operatorv1alpha1.MetalLBLoadBalancerProvider:
+		if contour.Spec.NetworkPublishing.Envoy.LoadBalancer.ProviderParameters.AWS != nil ||
+			contour.Spec.NetworkPublishing.Envoy.LoadBalancer.ProviderParameters.Azure != nil ||
+			contour.Spec.NetworkPublishing.Envoy.LoadBalancer.ProviderParameters.GCP != nil {
+			return fmt.Errorf("metallb provider chosen, other providers parameters should not be specified")
+		}
 	}
 
 	return nil